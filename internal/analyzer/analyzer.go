@@ -1,105 +1,684 @@
 package analyzer
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"math"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/chaksack/nada/internal/cache"
+	vcsgit "github.com/chaksack/nada/internal/git"
+	"github.com/chaksack/nada/internal/ignore"
+	"github.com/chaksack/nada/internal/metrics/halstead"
 	"github.com/chaksack/nada/internal/rules"
+	"github.com/chaksack/nada/internal/typecheck"
 	"github.com/chaksack/nada/internal/types"
 )
 
 // CodeAnalyzer performs static code analysis on Go projects
 type CodeAnalyzer struct {
-	fileSet    *token.FileSet
-	issues     []types.Issue
-	metrics    types.Metrics
-	filesCount int
-	options    types.AnalysisOptions
-	ruleEngine *rules.Engine
+	fileSet     *token.FileSet
+	issues      []types.Issue
+	metrics     types.Metrics
+	filesCount  int
+	options     types.AnalysisOptions
+	ruleEngine  *rules.Engine
+	baseline    *types.Report
+	ignoreFile  *ignore.File
+	cache       *cache.Cache
+	rulesetHash string
+	progress    chan<- types.ProgressEvent
 }
 
 // New creates a new CodeAnalyzer instance
 func New(options types.AnalysisOptions) *CodeAnalyzer {
+	analysisCache := cache.Open(options.ProjectPath)
+	if options.NoCache {
+		analysisCache = cache.Noop()
+	} else if options.CacheMaxBytes > 0 {
+		analysisCache.SetMaxBytes(options.CacheMaxBytes)
+	}
+
 	return &CodeAnalyzer{
 		fileSet:    token.NewFileSet(),
 		issues:     make([]types.Issue, 0),
 		options:    options,
 		ruleEngine: rules.NewEngine(),
+		cache:      analysisCache,
+	}
+}
+
+// RuleEngine returns the rule engine used by this analyzer, so reporters can
+// build a rule catalog (e.g. SARIF tool.driver.rules) without re-registering rules.
+func (ca *CodeAnalyzer) RuleEngine() *rules.Engine {
+	return ca.ruleEngine
+}
+
+// LoadBaseline reads a previously saved JSON report from path and remembers
+// it so callers can compare a fresh AnalyzeProject result against it via
+// Report.Diff, surfacing only newly introduced issues in CI.
+func (ca *CodeAnalyzer) LoadBaseline(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var baseline types.Report
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return fmt.Errorf("failed to parse baseline file: %w", err)
+	}
+
+	ca.baseline = &baseline
+	return nil
+}
+
+// Baseline returns the report loaded via LoadBaseline, or nil if none was loaded.
+func (ca *CodeAnalyzer) Baseline() *types.Report {
+	return ca.baseline
+}
+
+// LoadIgnoreFile reads the .nadaignore checksum baseline at path and
+// remembers it so AnalyzeProject can drop its suppressed rule/file pairs
+// (or resurface them as baseline-stale) before issues are merged in. A
+// missing file is not an error, since most projects won't have one.
+func (ca *CodeAnalyzer) LoadIgnoreFile(path string) error {
+	file, err := ignore.Load(path)
+	if err != nil {
+		return fmt.Errorf("failed to read ignore file: %w", err)
 	}
+
+	ca.ignoreFile = file
+	return nil
 }
 
-// AnalyzeProject analyzes the entire project and returns a report
+// SetProgress registers a channel that receives a types.ProgressEvent after
+// each file finishes analysis, for callers that want to render a live
+// progress indicator (see reporter.Progress). Events are sent non-blocking,
+// so a renderer that falls behind drops events rather than stalling the
+// worker pool's collector loop.
+func (ca *CodeAnalyzer) SetProgress(ch chan<- types.ProgressEvent) {
+	ca.progress = ch
+}
+
+// AnalyzeProject analyzes the entire project and returns a report. Files are
+// discovered up front, then parsed and rule-checked concurrently by a
+// bounded worker pool (see analyzeFilesParallel) before metrics and scores
+// are computed from the merged results.
 func (ca *CodeAnalyzer) AnalyzeProject() (*types.Report, error) {
 	if ca.options.Verbose {
 		fmt.Printf("🔍 Analyzing project: %s\n", ca.options.ProjectPath)
 	}
 
-	// Walk through project directory
-	err := filepath.WalkDir(ca.options.ProjectPath, ca.walkFunc)
+	files, err := ca.discoverFiles()
 	if err != nil {
 		return nil, fmt.Errorf("error walking directory: %w", err)
 	}
 
+	ca.rulesetHash = cache.HashRuleset(ruleIDs(ca.ruleEngine))
+	ca.analyzeFilesParallel(files)
+	ca.analyzePackages(files)
+	ca.analyzeTyped()
+
+	if ca.options.DiffBase != "" {
+		if err := ca.filterToDiffBase(); err != nil {
+			if ca.options.Verbose {
+				fmt.Printf("⚠️  skipping --diff-base line filtering: %v\n", err)
+			}
+		}
+	}
+
+	if len(ca.options.Linters) > 0 || len(ca.ruleEngine.GetExternalLinters()) > 0 {
+		ca.runExternalLinters()
+	}
+
+	if err := ca.cache.Save(); err != nil && ca.options.Verbose {
+		fmt.Printf("⚠️  failed to save analysis cache: %v\n", err)
+	}
+
+	hotspots, err := ca.computeHotspots(hotspotWindow)
+	if err != nil {
+		if ca.options.Verbose {
+			fmt.Printf("⚠️  skipping git-based hotspot analysis: %v\n", err)
+		}
+	} else {
+		ca.attributeOwners()
+	}
+
 	// Calculate final metrics and scores
 	score := ca.calculateScore()
 	grade := ca.calculateGrade(score)
 	trends := ca.calculateQualityTrends()
-	recommendations := ca.generateRecommendations()
+	recommendations := ca.generateRecommendations(hotspots)
 
 	report := &types.Report{
-		ProjectPath:     ca.options.ProjectPath,
-		Timestamp:       time.Now(),
-		Issues:          ca.issues,
-		Metrics:         ca.metrics,
-		FilesAnalyzed:   ca.filesCount,
-		IssuesSummary:   ca.getIssuesSummary(),
-		Score:           score,
-		Grade:           grade,
-		Trends:          trends,
-		Recommendations: recommendations,
+		ProjectPath:        ca.options.ProjectPath,
+		Timestamp:          time.Now(),
+		Issues:             ca.issues,
+		Metrics:            ca.metrics,
+		FilesAnalyzed:      ca.filesCount,
+		IssuesSummary:      ca.getIssuesSummary(),
+		Score:              score,
+		Grade:              grade,
+		Trends:             trends,
+		Recommendations:    recommendations,
+		Hotspots:           hotspots,
+		UnusedSuppressions: ca.ruleEngine.UnusedSuppressions(),
 	}
 
 	return report, nil
 }
 
-// walkFunc is called for each file during directory traversal
-func (ca *CodeAnalyzer) walkFunc(path string, d os.DirEntry, err error) error {
-	if err != nil {
-		return err
+// hotspotWindow is how far back Churn looks when scoring files by recent
+// commit activity.
+const hotspotWindow = 90 * 24 * time.Hour
+
+// discoverFiles walks the project directory and returns the paths of every
+// Go file that should be analyzed, honoring the same skip rules the old
+// serial walkFunc applied (vendor/.git, test files, exclude patterns), plus
+// the changed-file set when options.Since, options.DiffBase, or
+// options.DiffTarget is set (checked in that order of precedence, since
+// Since and DiffBase are the more specific, newer flags and DiffTarget is
+// --diff's older, file-only alias for the same git ref).
+func (ca *CodeAnalyzer) discoverFiles() ([]string, error) {
+	var since map[string]bool
+	switch {
+	case ca.options.Since != "":
+		var err error
+		since, err = ca.changedPackageFiles(ca.options.Since)
+		if err != nil {
+			return nil, fmt.Errorf("resolving --since %q: %w", ca.options.Since, err)
+		}
+	case ca.options.DiffBase != "":
+		var err error
+		since, err = ca.changedPackageFiles(ca.options.DiffBase)
+		if err != nil {
+			return nil, fmt.Errorf("resolving --diff-base %q: %w", ca.options.DiffBase, err)
+		}
+	case ca.options.DiffTarget != "":
+		var err error
+		since, err = ca.changedPackageFiles(ca.options.DiffTarget)
+		if err != nil {
+			return nil, fmt.Errorf("resolving --diff %q: %w", ca.options.DiffTarget, err)
+		}
 	}
 
-	// Skip directories and non-Go files
-	if d.IsDir() || !strings.HasSuffix(path, ".go") {
+	var files []string
+
+	err := filepath.WalkDir(ca.options.ProjectPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		if strings.Contains(path, "vendor/") || strings.Contains(path, ".git/") {
+			return nil
+		}
+
+		if !ca.options.IncludeTests && strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		for _, exclude := range ca.options.ExcludeFiles {
+			if matched, _ := filepath.Match(exclude, filepath.Base(path)); matched {
+				return nil
+			}
+		}
+
+		if since != nil && !since[path] {
+			return nil
+		}
+
+		files = append(files, path)
 		return nil
+	})
+
+	return files, err
+}
+
+// changedPackageFiles resolves options.Since to the absolute paths of files
+// a PR gate should analyze: every .go file git reports as changed, plus
+// every other file in the same directory, since Go's unit of compilation is
+// the package and a change to one file can affect diagnostics (unused
+// imports, complexity contributed by a shared helper, ...) reported against
+// its siblings. This directory-level grouping stands in for a true
+// reverse-dependency closure across the whole import graph, which would
+// need a type-checked module graph built via golang.org/x/tools/go/packages
+// - unavailable without a go.mod in this tree (see the AnalyzerAdapter
+// comment in rules/external.go for the same constraint). It's deliberately
+// conservative about what it misses: a caller that changes a package's
+// exported API without also touching its importers won't see those
+// importers re-analyzed by --since alone.
+func (ca *CodeAnalyzer) changedPackageFiles(ref string) (map[string]bool, error) {
+	changed, err := vcsgit.ChangedFiles(ca.options.ProjectPath, ref)
+	if err != nil {
+		return nil, err
 	}
 
-	// Skip vendor and .git directories
-	if strings.Contains(path, "vendor/") || strings.Contains(path, ".git/") {
-		return nil
+	result := make(map[string]bool, len(changed))
+	dirs := make(map[string]bool)
+	for _, rel := range changed {
+		if !strings.HasSuffix(rel, ".go") {
+			continue
+		}
+		abs := filepath.Join(ca.options.ProjectPath, rel)
+		result[abs] = true
+		dirs[filepath.Dir(abs)] = true
 	}
 
-	// Skip test files if not included
-	if !ca.options.IncludeTests && strings.HasSuffix(path, "_test.go") {
-		return nil
+	for dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+				continue
+			}
+			result[filepath.Join(dir, entry.Name())] = true
+		}
 	}
 
-	// Skip excluded files
-	for _, exclude := range ca.options.ExcludeFiles {
-		if matched, _ := filepath.Match(exclude, filepath.Base(path)); matched {
-			return nil
+	return result, nil
+}
+
+// filterToDiffBase narrows ca.issues down to those reported against a line
+// `git diff -U0 options.DiffBase` actually touched, using vcsgit.ChangedLines
+// per file. discoverFiles already restricted the analyzed file set to
+// changed files plus their same-package siblings (see changedPackageFiles);
+// this second pass goes further, dropping issues in sibling-only files
+// entirely (they were only analyzed for compilation/type context) and
+// issues in directly-changed files that sit on untouched lines - the same
+// "--new-from-rev" semantics golangci-lint uses to let legacy debt through
+// while still blocking regressions on lines a PR actually edited.
+func (ca *CodeAnalyzer) filterToDiffBase() error {
+	changed, err := vcsgit.ChangedFiles(ca.options.ProjectPath, ca.options.DiffBase)
+	if err != nil {
+		return err
+	}
+
+	directlyChanged := make(map[string]bool, len(changed))
+	for _, rel := range changed {
+		directlyChanged[filepath.Join(ca.options.ProjectPath, rel)] = true
+	}
+
+	linesByFile := make(map[string]map[int]bool)
+	kept := make([]types.Issue, 0, len(ca.issues))
+	for _, issue := range ca.issues {
+		if !directlyChanged[issue.File] {
+			continue
+		}
+
+		lines, ok := linesByFile[issue.File]
+		if !ok {
+			rel, relErr := filepath.Rel(ca.options.ProjectPath, issue.File)
+			if relErr != nil {
+				rel = issue.File
+			}
+			lines, err = vcsgit.ChangedLines(ca.options.ProjectPath, ca.options.DiffBase, rel)
+			if err != nil {
+				return err
+			}
+			linesByFile[issue.File] = lines
+		}
+
+		if lines[issue.Line] {
+			kept = append(kept, issue)
 		}
 	}
 
-	ca.analyzeFile(path)
+	ca.issues = kept
 	return nil
 }
 
+// runExternalLinters shells out to the linters named in options.Linters
+// (see rules.NewLinterAggregator) plus any rules.ExternalLinter registered
+// directly on the rule engine via RegisterExternal, and merges their
+// findings into ca.issues, deduplicated against both each other and the
+// AST-based rules' own output by (File, Line, Rule). A linter that fails to
+// run (missing binary, unparseable output) is dropped silently in
+// non-verbose mode, the same tolerance filterToDiffBase and computeHotspots
+// give an unavailable git.
+func (ca *CodeAnalyzer) runExternalLinters() {
+	aggregator := rules.NewLinterAggregator(ca.options.Linters)
+	aggregator.Linters = append(aggregator.Linters, ca.ruleEngine.GetExternalLinters()...)
+	issues, err := aggregator.Run(ca.options.ProjectPath)
+	if err != nil {
+		if ca.options.Verbose {
+			fmt.Printf("⚠️  external linters: %v\n", err)
+		}
+		return
+	}
+
+	combined := append(append([]types.Issue{}, ca.issues...), issues...)
+	seen := make(map[string]bool, len(combined))
+	deduped := make([]types.Issue, 0, len(combined))
+	for _, issue := range combined {
+		key := fmt.Sprintf("%s|%d|%s", issue.File, issue.Line, issue.Rule)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, issue)
+	}
+	ca.issues = deduped
+}
+
+// analyzeFilesParallel runs the parser and rule engine over files using a
+// bounded worker pool (default runtime.NumCPU()), then merges each worker's
+// result into the analyzer's shared state on the calling goroutine so
+// ca.issues/ca.metrics/ca.filesCount never need external locking. Each
+// worker owns its own *token.FileSet, since token.FileSet is not safe for
+// concurrent AddFile calls.
+func (ca *CodeAnalyzer) analyzeFilesParallel(files []string) {
+	if len(files) == 0 {
+		return
+	}
+
+	workers := ca.options.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	pathCh := make(chan string)
+	resultCh := make(chan fileAnalysisResult)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			fset := token.NewFileSet()
+			for path := range pathCh {
+				resultCh <- ca.analyzeFileConcurrent(fset, path)
+			}
+		}()
+	}
+
+	go func() {
+		for _, path := range files {
+			pathCh <- path
+		}
+		close(pathCh)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	start := time.Now()
+	processed := 0
+	for result := range resultCh {
+		processed++
+		ca.mergeResult(result)
+		if ca.options.Verbose {
+			ca.printProgress(processed, len(files), start)
+		}
+		if ca.progress != nil {
+			select {
+			case ca.progress <- types.ProgressEvent{File: result.path, FilesDone: processed, FilesTotal: len(files), Issues: result.issues}:
+			default:
+			}
+		}
+	}
+}
+
+// analyzePackages runs the rule engine's registered PackageRules by
+// re-parsing files (the same list analyzeFilesParallel just analyzed)
+// grouped by directory - Go's unit of compilation - into a private
+// FileSet. This is a second, dedicated parse pass rather than folding
+// package rules into analyzeFilesParallel's worker pool: that pool
+// intentionally discards each file's *ast.File as soon as its own issues
+// are collected to bound memory, and package-scoped rules are the one
+// consumer that needs every file of a package alive at once. A no-op when
+// no PackageRule is registered, so the common case pays only a map lookup.
+func (ca *CodeAnalyzer) analyzePackages(files []string) {
+	if len(ca.ruleEngine.GetPackageRules()) == 0 || len(files) == 0 {
+		return
+	}
+
+	fset := token.NewFileSet()
+	pkgs := make(map[string][]*ast.File)
+	contents := make(map[string][]byte)
+
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		node, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		dir := filepath.Dir(path)
+		pkgs[dir] = append(pkgs[dir], node)
+		contents[path] = content
+	}
+
+	issues := ca.ruleEngine.AnalyzePackages(pkgs, fset)
+	for i := range issues {
+		content, ok := contents[issues[i].File]
+		if !ok {
+			continue
+		}
+		issues[i].Fingerprint = ca.computeFingerprint(issues[i], strings.Split(string(content), "\n"))
+	}
+
+	ca.issues = append(ca.issues, issues...)
+}
+
+// analyzeTyped loads and type-checks the whole module with package
+// typecheck and runs the rule engine's registered TypedRules against it. A
+// no-op when no TypedRule is registered, the same guard analyzePackages
+// uses, since Load walks and type-checks every package of the module - not
+// cheap work to do unconditionally. A module that fails to load (no
+// go.mod above the project path, an unparseable package) is skipped with a
+// verbose-only warning, the same tolerance runExternalLinters and
+// computeHotspots give an unavailable external dependency.
+func (ca *CodeAnalyzer) analyzeTyped() {
+	if len(ca.ruleEngine.GetTypedRules()) == 0 {
+		return
+	}
+
+	prog, err := typecheck.Load(ca.options.ProjectPath)
+	if err != nil {
+		if ca.options.Verbose {
+			fmt.Printf("⚠️  skipping type-aware rules: %v\n", err)
+		}
+		return
+	}
+
+	issues := ca.ruleEngine.AnalyzeProgram(prog)
+	contents := make(map[string][]byte)
+	for i := range issues {
+		content, ok := contents[issues[i].File]
+		if !ok {
+			content, err = os.ReadFile(issues[i].File)
+			if err != nil {
+				continue
+			}
+			contents[issues[i].File] = content
+		}
+		issues[i].Fingerprint = ca.computeFingerprint(issues[i], strings.Split(string(content), "\n"))
+	}
+
+	ca.issues = append(ca.issues, issues...)
+}
+
+// fileAnalysisResult is what a worker hands back to the collector loop; it
+// carries everything analyzeFile used to mutate on ca directly.
+type fileAnalysisResult struct {
+	path                 string
+	issues               []types.Issue
+	linesOfCode          int
+	cyclomaticComplexity int
+	cognitiveComplexity  int
+	hasTestFunc          bool
+	perFunction          []types.FunctionMetrics
+	contentHash          string
+	fromCache            bool
+}
+
+// ruleIDs collects a rule engine's registered rule IDs, used to derive a
+// cache-invalidating hash of the exact ruleset in effect.
+func ruleIDs(engine *rules.Engine) []string {
+	registered := engine.GetRules()
+	ids := make([]string, len(registered))
+	for i, rule := range registered {
+		ids[i] = rule.ID()
+	}
+	return ids
+}
+
+// analyzeFileConcurrent is the worker-safe counterpart to analyzeFile: it
+// reads only from ca.ruleEngine/ca.options (both immutable after New) and
+// never touches ca.issues/ca.metrics/ca.filesCount, so it can run on any
+// number of goroutines against a caller-owned FileSet.
+func (ca *CodeAnalyzer) analyzeFileConcurrent(fset *token.FileSet, filePath string) fileAnalysisResult {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fileAnalysisResult{
+			path: filePath,
+			issues: []types.Issue{{
+				Type:        types.TypeError,
+				Severity:    types.SeverityHigh,
+				File:        filePath,
+				Line:        1,
+				Column:      1,
+				Rule:        "read_error",
+				Message:     "Cannot read file",
+				Description: fmt.Sprintf("Error reading file: %v", err),
+				Impact:      types.IssueImpact{EffortMinutes: 1},
+			}},
+		}
+	}
+
+	contentHash := cache.HashContent(content)
+	if entry, ok := ca.cache.Lookup(filePath, contentHash, ca.rulesetHash); ok {
+		return fileAnalysisResult{
+			path:                 filePath,
+			issues:               entry.Issues,
+			linesOfCode:          entry.LinesOfCode,
+			cyclomaticComplexity: entry.CyclomaticComplexity,
+			cognitiveComplexity:  entry.CognitiveComplexity,
+			hasTestFunc:          entry.HasTestFunc,
+			perFunction:          entry.PerFunction,
+			contentHash:          contentHash,
+			fromCache:            true,
+		}
+	}
+
+	node, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
+	if err != nil {
+		return fileAnalysisResult{
+			path: filePath,
+			issues: []types.Issue{{
+				Type:        types.TypeError,
+				Severity:    types.SeverityHigh,
+				File:        filePath,
+				Line:        1,
+				Column:      1,
+				Rule:        "parse_error",
+				Message:     "Syntax error",
+				Description: fmt.Sprintf("Parse error: %v", err),
+				Impact:      types.IssueImpact{EffortMinutes: 1},
+			}},
+		}
+	}
+
+	fileIssues := ca.ruleEngine.AnalyzeFile(filePath, node, string(content), fset)
+
+	lines := strings.Split(string(content), "\n")
+	for i := range fileIssues {
+		fileIssues[i].Fingerprint = ca.computeFingerprint(fileIssues[i], lines)
+	}
+
+	return fileAnalysisResult{
+		path:                 filePath,
+		issues:               fileIssues,
+		linesOfCode:          len(lines),
+		cyclomaticComplexity: ca.calculateFileComplexity(node),
+		cognitiveComplexity:  ca.calculateFileCognitiveComplexity(node),
+		hasTestFunc:          strings.Contains(string(content), "func Test"),
+		perFunction:          computeFunctionMetrics(fset, filePath, node),
+		contentHash:          contentHash,
+	}
+}
+
+// mergeResult folds a worker's result into the analyzer's shared state. It
+// must only be called from the analyzeFilesParallel collector goroutine.
+func (ca *CodeAnalyzer) mergeResult(result fileAnalysisResult) {
+	ca.filesCount++
+
+	if ca.options.Verbose {
+		fmt.Printf("📄 Analyzing: %s\n", result.path)
+	}
+
+	issues := result.issues
+	if ca.ignoreFile != nil && result.contentHash != "" {
+		issues = ca.ignoreFile.Apply(result.path, result.contentHash, issues)
+	}
+
+	ca.issues = append(ca.issues, issues...)
+	ca.metrics.LinesOfCode += result.linesOfCode
+	ca.metrics.CyclomaticComplexity += result.cyclomaticComplexity
+	ca.metrics.CognitiveComplexity += result.cognitiveComplexity
+	ca.metrics.PerFunction = append(ca.metrics.PerFunction, result.perFunction...)
+
+	if !result.fromCache && result.contentHash != "" {
+		ca.cache.Store(result.path, cache.Entry{
+			ContentHash:          result.contentHash,
+			RulesetHash:          ca.rulesetHash,
+			Issues:               result.issues,
+			LinesOfCode:          result.linesOfCode,
+			CyclomaticComplexity: result.cyclomaticComplexity,
+			CognitiveComplexity:  result.cognitiveComplexity,
+			HasTestFunc:          result.hasTestFunc,
+			PerFunction:          result.perFunction,
+		})
+	}
+
+	if result.hasTestFunc {
+		ca.metrics.TestCoverage = float64(ca.filesCount) / float64(max(ca.filesCount, 1)) * 100
+	}
+}
+
+// printProgress renders a single-line progress indicator with an ETA
+// extrapolated from the average time per file processed so far, similar to
+// the running-total scan reporting other static analyzers show for large
+// repos.
+func (ca *CodeAnalyzer) printProgress(processed, total int, start time.Time) {
+	if total == 0 {
+		return
+	}
+
+	elapsed := time.Since(start)
+	var eta time.Duration
+	if rate := float64(processed) / elapsed.Seconds(); rate > 0 {
+		eta = time.Duration(float64(total-processed)/rate) * time.Second
+	}
+
+	fmt.Printf("\r⏳ Progress: %d/%d files (ETA: %s)", processed, total, eta.Round(time.Second))
+	if processed == total {
+		fmt.Println()
+	}
+}
+
 // analyzeFile analyzes a single Go file
 func (ca *CodeAnalyzer) analyzeFile(filePath string) {
 	ca.filesCount++
@@ -144,20 +723,29 @@ func (ca *CodeAnalyzer) analyzeFile(filePath string) {
 
 	// Apply rule engine
 	fileIssues := ca.ruleEngine.AnalyzeFile(filePath, node, string(content), ca.fileSet)
+
+	lines := strings.Split(string(content), "\n")
+	for i := range fileIssues {
+		fileIssues[i].Fingerprint = ca.computeFingerprint(fileIssues[i], lines)
+	}
+
 	ca.issues = append(ca.issues, fileIssues...)
 
 	// Update metrics
-	ca.updateMetrics(string(content), node)
+	ca.updateMetrics(filePath, string(content), node)
 }
 
 // updateMetrics updates the code metrics based on file analysis
-func (ca *CodeAnalyzer) updateMetrics(content string, node *ast.File) {
+func (ca *CodeAnalyzer) updateMetrics(filePath, content string, node *ast.File) {
 	lines := strings.Split(content, "\n")
 	ca.metrics.LinesOfCode += len(lines)
 
 	// Calculate cyclomatic complexity
 	complexity := ca.calculateFileComplexity(node)
 	ca.metrics.CyclomaticComplexity += complexity
+	ca.metrics.CognitiveComplexity += ca.calculateFileCognitiveComplexity(node)
+
+	ca.metrics.PerFunction = append(ca.metrics.PerFunction, computeFunctionMetrics(ca.fileSet, filePath, node)...)
 
 	// Update test coverage estimation
 	if strings.Contains(content, "func Test") {
@@ -185,6 +773,105 @@ func (ca *CodeAnalyzer) calculateFileComplexity(node *ast.File) int {
 	return complexity
 }
 
+// calculateFileCognitiveComplexity sums rules.CognitiveComplexity over every
+// top-level function in node, mirroring calculateFileComplexity's per-file
+// rollup of cyclomatic complexity.
+func (ca *CodeAnalyzer) calculateFileCognitiveComplexity(node *ast.File) int {
+	complexity := 0
+	for _, decl := range node.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			complexity += rules.CognitiveComplexity(fn)
+		}
+	}
+	return complexity
+}
+
+// computeFunctionMetrics returns per-function complexity, Halstead volume
+// and maintainability index for every top-level function declared in node.
+func computeFunctionMetrics(fset *token.FileSet, filePath string, node *ast.File) []types.FunctionMetrics {
+	var results []types.FunctionMetrics
+
+	for _, decl := range node.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+
+		complexity := functionComplexity(fn)
+		volume := halstead.Analyze(fn.Body).Volume
+		loc := fset.Position(fn.Body.Rbrace).Line - fset.Position(fn.Body.Lbrace).Line + 1
+
+		results = append(results, types.FunctionMetrics{
+			Name:                 fn.Name.Name,
+			File:                 filePath,
+			Line:                 fset.Position(fn.Pos()).Line,
+			LinesOfCode:          loc,
+			CyclomaticComplexity: complexity,
+			CognitiveComplexity:  rules.CognitiveComplexity(fn),
+			HalsteadVolume:       volume,
+			MaintainabilityIndex: maintainabilityIndex(volume, complexity, loc),
+		})
+	}
+
+	return results
+}
+
+// functionComplexity calculates the cyclomatic complexity of a single
+// function, mirroring calculateFileComplexity's decision-point counting but
+// scoped to one *ast.FuncDecl and starting from a base complexity of 1.
+func functionComplexity(fn *ast.FuncDecl) int {
+	complexity := 1
+
+	ast.Inspect(fn, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt,
+			*ast.TypeSwitchStmt, *ast.SelectStmt, *ast.CaseClause:
+			complexity++
+		}
+		return true
+	})
+
+	return complexity
+}
+
+// maintainabilityIndex implements the SEI Maintainability Index formula,
+// MI = 171 - 5.2*ln(HV) - 0.23*CC - 16.2*ln(LOC), scaled to a 0-100 range
+// as is common practice (Visual Studio and most modern tooling report MI
+// this way rather than the raw, unbounded value).
+func maintainabilityIndex(halsteadVolume float64, cyclomaticComplexity, linesOfCode int) float64 {
+	volume := math.Max(halsteadVolume, 1)
+	loc := math.Max(float64(linesOfCode), 1)
+
+	mi := 171 - 5.2*math.Log(volume) - 0.23*float64(cyclomaticComplexity) - 16.2*math.Log(loc)
+	scaled := mi * 100 / 171
+
+	if scaled < 0 {
+		scaled = 0
+	}
+	if scaled > 100 {
+		scaled = 100
+	}
+
+	return scaled
+}
+
+// averageMaintainabilityIndex aggregates per-function maintainability index
+// values into a single project-wide figure. With no functions analyzed yet
+// it returns 100 (nothing to penalize), matching the "good until proven
+// otherwise" default the rest of calculateQualityTrends uses.
+func averageMaintainabilityIndex(perFunction []types.FunctionMetrics) float64 {
+	if len(perFunction) == 0 {
+		return 100
+	}
+
+	total := 0.0
+	for _, fn := range perFunction {
+		total += fn.MaintainabilityIndex
+	}
+
+	return total / float64(len(perFunction))
+}
+
 // addIssue adds a new issue to the analyzer
 func (ca *CodeAnalyzer) addIssue(issue types.Issue) {
 	ca.issues = append(ca.issues, issue)
@@ -279,8 +966,7 @@ func (ca *CodeAnalyzer) calculateQualityTrends() types.QualityTrends {
 		securityIssues := summary[types.TypeVulnerability]
 		trends.SecurityScore = max(0, 100-float64(securityIssues*10))
 
-		maintainabilityDeductions := float64(securityIssues*5 + summary["missing_documentation"]*2)
-		trends.MaintainabilityIndex = max(0, 100-maintainabilityDeductions)
+		trends.MaintainabilityIndex = averageMaintainabilityIndex(ca.metrics.PerFunction)
 
 		totalDebtMinutes := 0
 		for _, issue := range ca.issues {
@@ -295,7 +981,7 @@ func (ca *CodeAnalyzer) calculateQualityTrends() types.QualityTrends {
 }
 
 // generateRecommendations generates actionable recommendations
-func (ca *CodeAnalyzer) generateRecommendations() []string {
+func (ca *CodeAnalyzer) generateRecommendations(hotspots []types.Hotspot) []string {
 	var recommendations []string
 	summary := ca.getIssuesSummary()
 
@@ -314,6 +1000,14 @@ func (ca *CodeAnalyzer) generateRecommendations() []string {
 			fmt.Sprintf("🧪 Increase test coverage from %.1f%% to at least 70%%", ca.metrics.TestCoverage))
 	}
 
+	for i, hotspot := range hotspots {
+		if i >= 3 {
+			break
+		}
+		recommendations = append(recommendations,
+			fmt.Sprintf("🔥 HOTSPOT: %s changes often and is complex/issue-prone - prioritize refactoring it", hotspot.File))
+	}
+
 	if len(recommendations) == 0 {
 		recommendations = append(recommendations,
 			"✅ Great job! Your code quality is excellent.")
@@ -322,6 +1016,91 @@ func (ca *CodeAnalyzer) generateRecommendations() []string {
 	return recommendations
 }
 
+// computeHotspots ranks analyzed files by combining recent git churn (via
+// the internal/git subsystem) with their cyclomatic complexity and issue
+// count, so the riskiest files to leave unrefactored surface first. A
+// project that isn't a git repository (or has no git binary available)
+// simply yields no hotspots - this is deliberately non-fatal since hotspot
+// scoring is a bonus on top of the static analysis this tool already does.
+func (ca *CodeAnalyzer) computeHotspots(since time.Duration) ([]types.Hotspot, error) {
+	churn, err := vcsgit.Churn(ca.options.ProjectPath, since)
+	if err != nil {
+		return nil, err
+	}
+	if len(churn) == 0 {
+		return nil, nil
+	}
+
+	complexityByFile := make(map[string]int)
+	for _, fn := range ca.metrics.PerFunction {
+		complexityByFile[ca.relFile(fn.File)] += fn.CyclomaticComplexity
+	}
+
+	issueCountByFile := make(map[string]int)
+	for _, issue := range ca.issues {
+		issueCountByFile[ca.relFile(issue.File)]++
+	}
+
+	maxCommits, maxComplexity, maxIssues := 1, 1, 1
+	for file, fc := range churn {
+		maxCommits = max(maxCommits, fc.CommitCount)
+		maxComplexity = max(maxComplexity, complexityByFile[file])
+		maxIssues = max(maxIssues, issueCountByFile[file])
+	}
+
+	hotspots := make([]types.Hotspot, 0, len(churn))
+	for file, fc := range churn {
+		complexity := complexityByFile[file]
+		issueCount := issueCountByFile[file]
+
+		normChurn := float64(fc.CommitCount) / float64(maxCommits)
+		normComplexity := float64(complexity) / float64(maxComplexity)
+		normIssues := float64(issueCount) / float64(maxIssues)
+
+		hotspots = append(hotspots, types.Hotspot{
+			File:        file,
+			Score:       normChurn * normComplexity * normIssues,
+			CommitCount: fc.CommitCount,
+			AuthorCount: len(fc.Authors),
+			Complexity:  complexity,
+			IssueCount:  issueCount,
+		})
+	}
+
+	sort.Slice(hotspots, func(i, j int) bool { return hotspots[i].Score > hotspots[j].Score })
+	if len(hotspots) > 20 {
+		hotspots = hotspots[:20]
+	}
+
+	return hotspots, nil
+}
+
+// attributeOwners sets each issue's Owner to the author who last touched
+// its line, via git blame. Only called once computeHotspots has confirmed
+// the project is a usable git repository.
+func (ca *CodeAnalyzer) attributeOwners() {
+	for i := range ca.issues {
+		if ca.issues[i].Line < 1 {
+			continue
+		}
+		if author, err := vcsgit.BlameAuthor(ca.options.ProjectPath, ca.relFile(ca.issues[i].File), ca.issues[i].Line); err == nil {
+			ca.issues[i].Owner = author
+		}
+	}
+}
+
+// relFile converts an analyzed file's path (which carries the
+// ProjectPath prefix produced by discoverFiles' WalkDir) into a path
+// relative to ProjectPath, matching the paths git log/blame report when run
+// with ProjectPath as their working directory.
+func (ca *CodeAnalyzer) relFile(file string) string {
+	rel, err := filepath.Rel(ca.options.ProjectPath, file)
+	if err != nil {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
+
 // Helper function for max
 func max[T ~int | ~float64](a, b T) T {
 	if a > b {
@@ -213,7 +213,7 @@ func TestGenerateRecommendations(t *testing.T) {
 			analyzer.issues = tt.issues
 			analyzer.metrics.TestCoverage = tt.testCoverage
 
-			recommendations := analyzer.generateRecommendations()
+			recommendations := analyzer.generateRecommendations(nil)
 
 			if len(recommendations) == 0 {
 				t.Errorf("generateRecommendations() returned empty slice")
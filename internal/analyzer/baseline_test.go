@@ -0,0 +1,129 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaksack/nada/internal/ignore"
+	"github.com/chaksack/nada/internal/types"
+)
+
+func TestLoadBaseline(t *testing.T) {
+	tmpDir := t.TempDir()
+	baselineFile := filepath.Join(tmpDir, "baseline.json")
+
+	baseline := &types.Report{
+		Grade: "B",
+		Issues: []types.Issue{
+			{Rule: "todo_comment", File: "main.go", Line: 5, Fingerprint: "abc123"},
+		},
+	}
+
+	data, err := json.Marshal(baseline)
+	if err != nil {
+		t.Fatalf("failed to marshal baseline: %v", err)
+	}
+	if err := os.WriteFile(baselineFile, data, 0644); err != nil {
+		t.Fatalf("failed to write baseline file: %v", err)
+	}
+
+	ca := New(types.AnalysisOptions{ProjectPath: tmpDir})
+
+	if ca.Baseline() != nil {
+		t.Fatal("expected no baseline before LoadBaseline is called")
+	}
+
+	if err := ca.LoadBaseline(baselineFile); err != nil {
+		t.Fatalf("LoadBaseline() failed: %v", err)
+	}
+
+	loaded := ca.Baseline()
+	if loaded == nil {
+		t.Fatal("Baseline() returned nil after LoadBaseline")
+	}
+	if loaded.Grade != "B" {
+		t.Errorf("Baseline().Grade = %v, want B", loaded.Grade)
+	}
+	if len(loaded.Issues) != 1 || loaded.Issues[0].Fingerprint != "abc123" {
+		t.Errorf("Baseline().Issues = %+v, want one issue with fingerprint abc123", loaded.Issues)
+	}
+}
+
+func TestLoadBaselineMissingFile(t *testing.T) {
+	ca := New(types.AnalysisOptions{ProjectPath: t.TempDir()})
+
+	if err := ca.LoadBaseline(filepath.Join(t.TempDir(), "does-not-exist.json")); err == nil {
+		t.Error("LoadBaseline() expected error for missing file, got nil")
+	}
+}
+
+func TestAnalyzeProjectAppliesIgnoreFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "main.go")
+	content := []byte("package main\n\nfunc main() {\n\t// TODO: fix this\n}\n")
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	ca := New(types.AnalysisOptions{ProjectPath: tmpDir})
+	if err := ca.LoadIgnoreFile(filepath.Join(tmpDir, ".nadaignore")); err != nil {
+		t.Fatalf("LoadIgnoreFile() error = %v", err)
+	}
+	ca.ignoreFile.Entries = append(ca.ignoreFile.Entries, ignore.Entry{
+		Filename:    filePath,
+		Checksum:    ignore.Checksum(content),
+		IgnoreRules: []string{"todo_comment"},
+	})
+
+	report, err := ca.AnalyzeProject()
+	if err != nil {
+		t.Fatalf("AnalyzeProject() error = %v", err)
+	}
+
+	for _, issue := range report.Issues {
+		if issue.Rule == "todo_comment" {
+			t.Errorf("expected todo_comment to be suppressed by the ignore file, found %+v", issue)
+		}
+	}
+}
+
+func TestAnalyzeFileFingerprintStableAcrossLineShift(t *testing.T) {
+	tmpDir := t.TempDir()
+	options := types.AnalysisOptions{ProjectPath: tmpDir, IncludeTests: true}
+	ca := New(options)
+
+	original := "package main\n\nfunc main() {\n\t// TODO: fix this\n}\n"
+	shifted := "package main\n\n\nfunc main() {\n\t// TODO: fix this\n}\n"
+
+	filePath := filepath.Join(tmpDir, "main.go")
+
+	if err := os.WriteFile(filePath, []byte(original), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	ca.analyzeFile(filePath)
+	before := fingerprintFor(t, ca.issues, "todo_comment")
+
+	ca2 := New(options)
+	if err := os.WriteFile(filePath, []byte(shifted), 0644); err != nil {
+		t.Fatalf("failed to write shifted test file: %v", err)
+	}
+	ca2.analyzeFile(filePath)
+	after := fingerprintFor(t, ca2.issues, "todo_comment")
+
+	if before != after {
+		t.Errorf("fingerprint changed after an unrelated blank-line insertion: %v != %v", before, after)
+	}
+}
+
+func fingerprintFor(t *testing.T, issues []types.Issue, rule string) string {
+	t.Helper()
+	for _, issue := range issues {
+		if issue.Rule == rule {
+			return issue.Fingerprint
+		}
+	}
+	t.Fatalf("no issue found for rule %q", rule)
+	return ""
+}
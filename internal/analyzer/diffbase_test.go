@@ -0,0 +1,58 @@
+package analyzer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func TestAnalyzeProjectDiffBaseFiltersToChangedLines(t *testing.T) {
+	tmpDir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test Author", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test Author", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	filePath := filepath.Join(tmpDir, "main.go")
+	original := "package main\n\nfunc main() {\n\t// TODO: old debt\n\tprintln(\"x\")\n}\n"
+	if err := os.WriteFile(filePath, []byte(original), 0644); err != nil {
+		t.Fatalf("failed to write main.go: %v", err)
+	}
+
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test Author")
+	run("add", ".")
+	run("commit", "-q", "-m", "initial commit")
+
+	updated := "package main\n\nfunc main() {\n\t// TODO: old debt\n\tprintln(\"x\")\n\t// TODO: new debt\n}\n"
+	if err := os.WriteFile(filePath, []byte(updated), 0644); err != nil {
+		t.Fatalf("failed to rewrite main.go: %v", err)
+	}
+	run("commit", "-q", "-am", "add a second TODO")
+
+	report, err := New(types.AnalysisOptions{ProjectPath: tmpDir, DiffBase: "HEAD~1"}).AnalyzeProject()
+	if err != nil {
+		t.Fatalf("AnalyzeProject() failed: %v", err)
+	}
+
+	var todoLines []int
+	for _, issue := range report.Issues {
+		if issue.Rule == "todo_comment" {
+			todoLines = append(todoLines, issue.Line)
+		}
+	}
+
+	if len(todoLines) != 1 || todoLines[0] != 6 {
+		t.Errorf("todo_comment issues = %v, want exactly [6] (the line added after HEAD~1)", todoLines)
+	}
+}
@@ -0,0 +1,42 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// computeFingerprint derives a stable identity for an issue from its rule,
+// repo-relative file, and the normalized source line plus its immediate
+// neighbours. Using the surrounding content (rather than just the line
+// number) keeps the fingerprint stable when unrelated insertions or
+// deletions shift line numbers elsewhere in the file.
+func (ca *CodeAnalyzer) computeFingerprint(issue types.Issue, lines []string) string {
+	relFile := issue.File
+	if rel, err := filepath.Rel(ca.options.ProjectPath, issue.File); err == nil {
+		relFile = filepath.ToSlash(rel)
+	}
+
+	snippet := normalizeLine(lineAt(lines, issue.Line))
+	context := normalizeLine(lineAt(lines, issue.Line-1)) + "|" + normalizeLine(lineAt(lines, issue.Line+1))
+
+	sum := sha256.Sum256([]byte(issue.Rule + "|" + relFile + "|" + snippet + "|" + context))
+	return hex.EncodeToString(sum[:])
+}
+
+// lineAt returns the 1-indexed line n, or "" if n is out of range.
+func lineAt(lines []string, n int) string {
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	return lines[n-1]
+}
+
+// normalizeLine collapses whitespace so formatting-only changes (indentation,
+// trailing spaces) don't change the fingerprint.
+func normalizeLine(line string) string {
+	return strings.Join(strings.Fields(line), " ")
+}
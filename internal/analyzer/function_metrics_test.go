@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func TestComputeFunctionMetrics(t *testing.T) {
+	src := `package sample
+
+func simple() int {
+	return 1
+}
+
+func branchy(items []int) int {
+	total := 0
+	for _, v := range items {
+		if v > 0 {
+			total += v
+		} else {
+			total -= v
+		}
+	}
+	return total
+}
+`
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "sample.go", src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	metrics := computeFunctionMetrics(fset, "sample.go", node)
+
+	if len(metrics) != 2 {
+		t.Fatalf("computeFunctionMetrics() returned %d functions, want 2", len(metrics))
+	}
+
+	byName := make(map[string]types.FunctionMetrics, len(metrics))
+	for _, m := range metrics {
+		byName[m.Name] = m
+	}
+
+	simple, ok := byName["simple"]
+	if !ok {
+		t.Fatal("computeFunctionMetrics() missing metrics for simple()")
+	}
+	branchy, ok := byName["branchy"]
+	if !ok {
+		t.Fatal("computeFunctionMetrics() missing metrics for branchy()")
+	}
+
+	if branchy.CyclomaticComplexity <= simple.CyclomaticComplexity {
+		t.Errorf("branchy().CyclomaticComplexity = %d, want > simple's %d",
+			branchy.CyclomaticComplexity, simple.CyclomaticComplexity)
+	}
+	if branchy.MaintainabilityIndex >= simple.MaintainabilityIndex {
+		t.Errorf("branchy().MaintainabilityIndex = %v, want < simple's %v (more complex code should score lower)",
+			branchy.MaintainabilityIndex, simple.MaintainabilityIndex)
+	}
+	for _, m := range metrics {
+		if m.MaintainabilityIndex < 0 || m.MaintainabilityIndex > 100 {
+			t.Errorf("%s.MaintainabilityIndex = %v, want in [0, 100]", m.Name, m.MaintainabilityIndex)
+		}
+	}
+}
+
+func TestAverageMaintainabilityIndexEmpty(t *testing.T) {
+	if got := averageMaintainabilityIndex(nil); got != 100 {
+		t.Errorf("averageMaintainabilityIndex(nil) = %v, want 100", got)
+	}
+}
+
+func TestAnalyzeFilePopulatesPerFunctionMetrics(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "main.go")
+	src := "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n"
+
+	if err := os.WriteFile(filePath, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	ca := New(types.AnalysisOptions{ProjectPath: tmpDir})
+	ca.analyzeFile(filePath)
+
+	if len(ca.metrics.PerFunction) != 1 {
+		t.Fatalf("metrics.PerFunction = %+v, want 1 entry", ca.metrics.PerFunction)
+	}
+	if ca.metrics.PerFunction[0].Name != "main" {
+		t.Errorf("metrics.PerFunction[0].Name = %v, want main", ca.metrics.PerFunction[0].Name)
+	}
+}
@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func initHotspotRepo(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test Author", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test Author", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test Author")
+
+	file := filepath.Join(dir, "main.go")
+	src := "package main\n\nfunc main() {\n\t// TODO: refactor\n\tif true {\n\t\tif true {\n\t\t\tprintln(\"hi\")\n\t\t}\n\t}\n}\n"
+	if err := os.WriteFile(file, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	run("add", ".")
+	run("commit", "-q", "-m", "initial commit")
+
+	return dir
+}
+
+func TestComputeHotspots(t *testing.T) {
+	dir := initHotspotRepo(t)
+
+	ca := New(types.AnalysisOptions{ProjectPath: dir})
+	if _, err := ca.AnalyzeProject(); err != nil {
+		t.Fatalf("AnalyzeProject() failed: %v", err)
+	}
+
+	hotspots, err := ca.computeHotspots(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("computeHotspots() failed: %v", err)
+	}
+
+	if len(hotspots) != 1 {
+		t.Fatalf("computeHotspots() = %+v, want 1 hotspot", hotspots)
+	}
+	if hotspots[0].File != "main.go" {
+		t.Errorf("hotspots[0].File = %v, want main.go", hotspots[0].File)
+	}
+	if hotspots[0].CommitCount != 1 {
+		t.Errorf("hotspots[0].CommitCount = %v, want 1", hotspots[0].CommitCount)
+	}
+}
+
+func TestComputeHotspotsNonGitProject(t *testing.T) {
+	ca := New(types.AnalysisOptions{ProjectPath: t.TempDir()})
+
+	hotspots, err := ca.computeHotspots(24 * time.Hour)
+	if err == nil {
+		t.Fatal("computeHotspots() on a non-git directory expected an error, got nil")
+	}
+	if hotspots != nil {
+		t.Errorf("computeHotspots() = %+v, want nil on error", hotspots)
+	}
+}
+
+func TestAnalyzeProjectAttributesOwners(t *testing.T) {
+	dir := initHotspotRepo(t)
+
+	ca := New(types.AnalysisOptions{ProjectPath: dir})
+	report, err := ca.AnalyzeProject()
+	if err != nil {
+		t.Fatalf("AnalyzeProject() failed: %v", err)
+	}
+
+	found := false
+	for _, issue := range report.Issues {
+		if issue.Owner == "Test Author" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("AnalyzeProject() did not attribute any issue to the committing author, got %+v", report.Issues)
+	}
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// synthCorpus writes n small Go files into a fresh temp directory, standing
+// in for the large-monorepo scenario --workers/--jobs targets. 10k files (the
+// scale named in the request this benchmarks) makes go test -bench too slow
+// to run routinely, so this uses a corpus two orders of magnitude smaller
+// that still keeps each worker busy long enough for the pool to matter.
+func synthCorpus(b *testing.B, n int) string {
+	b.Helper()
+	dir := b.TempDir()
+
+	for i := 0; i < n; i++ {
+		content := fmt.Sprintf(`package pkg%d
+
+func F%d(x int) int {
+	switch {
+	case x > 100:
+		return x * 2
+	case x > 0:
+		return x + 1
+	default:
+		return -x
+	}
+}
+`, i, i)
+		path := filepath.Join(dir, fmt.Sprintf("file%d.go", i))
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			b.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	return dir
+}
+
+// BenchmarkAnalyzeFilesParallelSingleWorker pins Workers to 1, giving a
+// serial-equivalent baseline to compare against
+// BenchmarkAnalyzeFilesParallelDefaultWorkers below.
+func BenchmarkAnalyzeFilesParallelSingleWorker(b *testing.B) {
+	dir := synthCorpus(b, 100)
+	options := types.AnalysisOptions{ProjectPath: dir, Workers: 1}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := New(options).AnalyzeProject(); err != nil {
+			b.Fatalf("AnalyzeProject() failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkAnalyzeFilesParallelDefaultWorkers leaves Workers at its default
+// (runtime.NumCPU()), exercising the pool analyzeFilesParallel fans work out
+// across. Run both benchmarks together (`go test -bench AnalyzeFilesParallel
+// -benchtime 5x ./internal/analyzer`) and compare ns/op to see the speedup
+// on a given machine; the gain scales with both CPU count and corpus size,
+// so a 2-core box analyzing 100 trivial files (where I/O and process
+// start-up dominate) may not show much over the single-worker baseline -
+// it's the large-monorepo case this pool exists for.
+func BenchmarkAnalyzeFilesParallelDefaultWorkers(b *testing.B) {
+	dir := synthCorpus(b, 100)
+	options := types.AnalysisOptions{ProjectPath: dir}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := New(options).AnalyzeProject(); err != nil {
+			b.Fatalf("AnalyzeProject() failed: %v", err)
+		}
+	}
+}
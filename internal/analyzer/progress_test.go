@@ -0,0 +1,42 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func TestAnalyzeProjectEmitsProgress(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"a.go", "b.go"} {
+		content := "package main\n\nfunc main() {}\n"
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+
+	ca := New(types.AnalysisOptions{ProjectPath: tmpDir})
+	events := make(chan types.ProgressEvent, 10)
+	ca.SetProgress(events)
+
+	if _, err := ca.AnalyzeProject(); err != nil {
+		t.Fatalf("AnalyzeProject() error = %v", err)
+	}
+	close(events)
+
+	var last types.ProgressEvent
+	count := 0
+	for evt := range events {
+		count++
+		last = evt
+	}
+
+	if count != 2 {
+		t.Fatalf("got %d progress events, want 2 (one per file)", count)
+	}
+	if last.FilesDone != 2 || last.FilesTotal != 2 {
+		t.Errorf("last event = %+v, want FilesDone=FilesTotal=2", last)
+	}
+}
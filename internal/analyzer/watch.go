@@ -0,0 +1,376 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chaksack/nada/internal/cache"
+	"github.com/chaksack/nada/internal/reporter"
+	"github.com/chaksack/nada/internal/types"
+)
+
+// watchPollInterval is how often Watch stats tracked files for changes.
+// fsnotify would push change events instead of polling, but this tree has
+// no go.mod to pull that module into (see the AnalyzerAdapter doc comment
+// in rules/external.go for the established rationale) - polling mtimes is
+// the stdlib-only substitute. A var rather than a const so tests can shrink
+// it.
+var watchPollInterval = 300 * time.Millisecond
+
+// watchDebounce coalesces a burst of writes (an editor's save-then-format,
+// a `git checkout` touching many files at once) into a single reanalysis
+// pass, instead of reanalyzing once per individual file change observed by
+// a poll.
+var watchDebounce = 200 * time.Millisecond
+
+// Watch analyzes every root once, then polls their .go files for changes
+// and re-analyzes only the affected packages - the changed file's own
+// package plus every package that (transitively) imports it, resolved from
+// each file's import declarations against the nearest enclosing module's
+// go.mod (see moduleImportPath) - sending an updated *types.Report on the
+// returned channel after each burst of changes settles. A file's content
+// hash gates reanalysis the same way AnalyzeProject's cache.Cache does, so
+// a dirty package whose files didn't actually change content (only an
+// mtime bump) costs a hash comparison rather than a reparse.
+//
+// Each report's Issues are tagged IsNew relative to the previous tick via
+// reporter.DiffAgainstBaseline, so a caller can render just what a change
+// introduced or fixed instead of the whole project's issue list every
+// time. The channel is closed, and the background goroutine exits, when
+// ctx is canceled; Watch itself returns as soon as the first report is
+// ready to send; a reporter.DiffAgainstBaseline(..., nil) start -> the
+// first report carries every issue tagged new. Surfacing this channel over
+// LSP's textDocument/publishDiagnostics is a natural follow-up, not done
+// here.
+func (ca *CodeAnalyzer) Watch(ctx context.Context, roots []string) (<-chan *types.Report, error) {
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("watch: no roots given")
+	}
+
+	w, err := newWatcher(roots)
+	if err != nil {
+		return nil, fmt.Errorf("watch: %w", err)
+	}
+
+	if ca.rulesetHash == "" {
+		ca.rulesetHash = cache.HashRuleset(ruleIDs(ca.ruleEngine))
+	}
+
+	out := make(chan *types.Report, 1)
+	first := ca.watchTick(w, nil)
+
+	go func() {
+		defer close(out)
+
+		select {
+		case out <- first:
+		case <-ctx.Done():
+			return
+		}
+		last := first
+
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !w.poll() {
+					continue
+				}
+
+				// Keep draining further changes until a full debounce
+				// window passes with nothing new, so a burst of writes
+				// triggers one reanalysis instead of many.
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(watchDebounce):
+					}
+					if !w.poll() {
+						break
+					}
+				}
+
+				next := ca.watchTick(w, last)
+				select {
+				case out <- next:
+					last = next
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// watcher tracks the .go files under Watch's roots, their last-seen mtimes,
+// and the package-level import graph used to decide which packages a
+// changed file invalidates.
+type watcher struct {
+	roots      []string
+	modTimes   map[string]time.Time          // file -> last observed mtime
+	imports    map[string][]string           // file -> resolved local package dirs it imports
+	modulePath string                        // module import path, e.g. "github.com/chaksack/nada"
+	moduleDir  string                        // directory containing the go.mod declaring modulePath
+	results    map[string]fileAnalysisResult // file -> last analysis result
+}
+
+// newWatcher discovers the initial file set under roots and resolves the
+// enclosing module's import path, returning an error only if no root can
+// be walked at all.
+func newWatcher(roots []string) (*watcher, error) {
+	w := &watcher{
+		roots:    roots,
+		modTimes: make(map[string]time.Time),
+		imports:  make(map[string][]string),
+		results:  make(map[string]fileAnalysisResult),
+	}
+
+	modulePath, moduleDir, err := moduleImportPath(roots[0])
+	if err == nil {
+		w.modulePath, w.moduleDir = modulePath, moduleDir
+	}
+
+	w.poll()
+	return w, nil
+}
+
+// poll re-walks w.roots and compares every discovered .go file's mtime
+// against what was last observed, updating w.modTimes in place. It reports
+// whether anything changed: a file added, modified, or removed since the
+// previous call.
+func (w *watcher) poll() bool {
+	seen := make(map[string]bool)
+	changed := false
+
+	for _, root := range w.roots {
+		_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			if strings.Contains(path, "vendor/") || strings.Contains(path, ".git/") {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+
+			seen[path] = true
+			if prev, ok := w.modTimes[path]; !ok || !prev.Equal(info.ModTime()) {
+				changed = true
+			}
+			w.modTimes[path] = info.ModTime()
+			return nil
+		})
+	}
+
+	for path := range w.modTimes {
+		if !seen[path] {
+			delete(w.modTimes, path)
+			delete(w.imports, path)
+			delete(w.results, path)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// watchTick reanalyzes every package poll found dirty since the previous
+// tick, merges the result with everything that's still current, and
+// returns a fresh *types.Report tagged IsNew relative to prev (or entirely
+// new if prev is nil, the first tick).
+func (ca *CodeAnalyzer) watchTick(w *watcher, prev *types.Report) *types.Report {
+	dirty := w.dirtyPackages()
+
+	fset := token.NewFileSet()
+	for path := range w.modTimes {
+		if !dirty[filepath.Dir(path)] {
+			continue
+		}
+
+		result := ca.analyzeFileConcurrent(fset, path)
+		w.results[path] = result
+		w.imports[path] = w.resolveImports(path)
+	}
+
+	ca.issues = nil
+	ca.metrics = types.Metrics{}
+	ca.filesCount = 0
+	for _, result := range w.results {
+		ca.mergeResult(result)
+	}
+
+	report := &types.Report{
+		ProjectPath:   strings.Join(w.roots, ","),
+		Timestamp:     time.Now(),
+		Issues:        ca.issues,
+		Metrics:       ca.metrics,
+		FilesAnalyzed: ca.filesCount,
+		IssuesSummary: ca.getIssuesSummary(),
+		Score:         ca.calculateScore(),
+	}
+	report.Grade = ca.calculateGrade(report.Score)
+
+	if prev == nil {
+		for i := range report.Issues {
+			report.Issues[i].IsNew = true
+		}
+		return report
+	}
+
+	tagged, _ := reporter.DiffAgainstBaseline(report, prev)
+	return tagged
+}
+
+// dirtyPackages returns the set of package directories (by filepath.Dir of
+// their files) that need reanalysis this tick: every package with a file
+// whose content hash actually changed, plus every package that
+// transitively imports one of those - a rename of an exported symbol
+// should re-flag its callers even though their own source didn't change.
+// A file new to w.modTimes (no cached result yet) always counts as
+// changed.
+func (w *watcher) dirtyPackages() map[string]bool {
+	changedPkgs := make(map[string]bool)
+	for path := range w.modTimes {
+		prev, ok := w.results[path]
+		if !ok {
+			changedPkgs[filepath.Dir(path)] = true
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if cache.HashContent(content) != prev.contentHash {
+			changedPkgs[filepath.Dir(path)] = true
+		}
+	}
+
+	// A package whose only file was deleted since the last tick also needs
+	// its former importers reanalyzed, even though it no longer has any
+	// files of its own left to walk above.
+	for path := range w.results {
+		if _, ok := w.modTimes[path]; !ok {
+			changedPkgs[filepath.Dir(path)] = true
+		}
+	}
+
+	if len(changedPkgs) == 0 {
+		return changedPkgs
+	}
+
+	reverse := w.reverseImportGraph()
+	dirty := make(map[string]bool, len(changedPkgs))
+	var mark func(pkg string)
+	mark = func(pkg string) {
+		if dirty[pkg] {
+			return
+		}
+		dirty[pkg] = true
+		for _, dependent := range reverse[pkg] {
+			mark(dependent)
+		}
+	}
+	for pkg := range changedPkgs {
+		mark(pkg)
+	}
+
+	return dirty
+}
+
+// reverseImportGraph inverts w.imports (file -> package dirs it imports)
+// into package dir -> package dirs that import it, using each file's
+// last-resolved import list. Files inside a dirty package get their import
+// list refreshed by watchTick before the next tick's reverseImportGraph
+// call, so this only ever lags behind by the one tick needed to discover a
+// newly added import.
+func (w *watcher) reverseImportGraph() map[string][]string {
+	reverse := make(map[string][]string)
+	for path, deps := range w.imports {
+		pkg := filepath.Dir(path)
+		for _, dep := range deps {
+			reverse[dep] = append(reverse[dep], pkg)
+		}
+	}
+	return reverse
+}
+
+// resolveImports parses path's import declarations (ast.ImportsOnly mode,
+// so it's cheap even for a large file) and returns the directories, within
+// this module, that it imports. Imports outside the module (stdlib or a
+// third-party dependency) can't invalidate anything nada tracks and are
+// skipped.
+func (w *watcher) resolveImports(path string) []string {
+	if w.modulePath == "" {
+		return nil
+	}
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, imp := range node.Imports {
+		importPath := strings.Trim(imp.Path.Value, `"`)
+		if importPath != w.modulePath && !strings.HasPrefix(importPath, w.modulePath+"/") {
+			continue
+		}
+
+		rel := strings.TrimPrefix(strings.TrimPrefix(importPath, w.modulePath), "/")
+		dirs = append(dirs, filepath.Join(w.moduleDir, filepath.FromSlash(rel)))
+	}
+
+	return dirs
+}
+
+// moduleImportPath finds the nearest ancestor of dir containing a go.mod
+// and returns its module directive's import path. It's a deliberately
+// minimal scanner rather than golang.org/x/mod/modfile - adding that
+// dependency isn't possible in this tree without a go.mod of nada's own,
+// the same constraint rules.parseGoModRequires documents.
+func moduleImportPath(dir string) (modulePath, moduleDir string, err error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	for {
+		data, err := os.ReadFile(filepath.Join(abs, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				line = strings.TrimSpace(line)
+				if strings.HasPrefix(line, "module ") {
+					return strings.TrimSpace(strings.TrimPrefix(line, "module")), abs, nil
+				}
+			}
+			return "", "", fmt.Errorf("go.mod at %s has no module directive", abs)
+		}
+
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "", "", os.ErrNotExist
+		}
+		abs = parent
+	}
+}
@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func writeTestModule(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/proj\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+}
+
+func TestModuleImportPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestModule(t, tmpDir)
+
+	sub := filepath.Join(tmpDir, "pkg", "sub")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("failed to create subdir: %v", err)
+	}
+
+	modulePath, moduleDir, err := moduleImportPath(sub)
+	if err != nil {
+		t.Fatalf("moduleImportPath() error = %v", err)
+	}
+	if modulePath != "example.com/proj" {
+		t.Errorf("modulePath = %q, want example.com/proj", modulePath)
+	}
+	if moduleDir != tmpDir {
+		t.Errorf("moduleDir = %q, want %q", moduleDir, tmpDir)
+	}
+}
+
+// TestWatchTagsIssuesAcrossTicks drives watchTick directly (rather than the
+// timer-based Watch loop) so the test stays fast and deterministic: a
+// changed file's new issue must show up on the next tick's report, tagged
+// IsNew relative to the previous one.
+func TestWatchTagsIssuesAcrossTicks(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestModule(t, tmpDir)
+
+	aPath := filepath.Join(tmpDir, "a.go")
+	if err := os.WriteFile(aPath, []byte("package proj\n\nfunc doWork() {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write a.go: %v", err)
+	}
+
+	ca := New(types.AnalysisOptions{ProjectPath: tmpDir})
+	w, err := newWatcher([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("newWatcher() error = %v", err)
+	}
+
+	first := ca.watchTick(w, nil)
+	if len(first.Issues) != 0 {
+		t.Fatalf("first tick Issues = %v, want none", first.Issues)
+	}
+
+	if err := os.WriteFile(aPath, []byte("package proj\n\n// TODO: revisit\nfunc doWork() {}\n"), 0644); err != nil {
+		t.Fatalf("failed to rewrite a.go: %v", err)
+	}
+	w.poll()
+
+	second := ca.watchTick(w, first)
+	found := false
+	for _, issue := range second.Issues {
+		if issue.Rule == "todo_comment" {
+			found = true
+			if !issue.IsNew {
+				t.Error("todo_comment issue on second tick should be tagged IsNew")
+			}
+		}
+	}
+	if !found {
+		t.Fatal("watchTick() did not surface the new todo_comment issue")
+	}
+}
+
+// TestWatchInvalidatesReverseDependents verifies that changing a package's
+// file marks every package that imports it dirty too, not just the
+// directly-changed one.
+func TestWatchInvalidatesReverseDependents(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestModule(t, tmpDir)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, "a"), 0755); err != nil {
+		t.Fatalf("failed to create a/: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "b"), 0755); err != nil {
+		t.Fatalf("failed to create b/: %v", err)
+	}
+
+	aPath := filepath.Join(tmpDir, "a", "a.go")
+	bPath := filepath.Join(tmpDir, "b", "b.go")
+	if err := os.WriteFile(aPath, []byte("package a\n\nfunc A() {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write a/a.go: %v", err)
+	}
+	if err := os.WriteFile(bPath, []byte("package b\n\nimport \"example.com/proj/a\"\n\nfunc B() { a.A() }\n"), 0644); err != nil {
+		t.Fatalf("failed to write b/b.go: %v", err)
+	}
+
+	ca := New(types.AnalysisOptions{ProjectPath: tmpDir})
+	w, err := newWatcher([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("newWatcher() error = %v", err)
+	}
+	ca.watchTick(w, nil)
+
+	if err := os.WriteFile(aPath, []byte("package a\n\n// TODO: rework\nfunc A() {}\n"), 0644); err != nil {
+		t.Fatalf("failed to rewrite a/a.go: %v", err)
+	}
+	w.poll()
+
+	dirty := w.dirtyPackages()
+	if !dirty[filepath.Dir(aPath)] {
+		t.Error("dirtyPackages() did not mark a/ dirty after its file changed")
+	}
+	if !dirty[filepath.Dir(bPath)] {
+		t.Error("dirtyPackages() did not mark b/ dirty, even though it imports a/")
+	}
+}
@@ -0,0 +1,281 @@
+// Package cache implements a persistent, file-hash-keyed store of per-file
+// analysis results, letting repeated runs over a large project skip
+// re-parsing and re-checking files that haven't changed since the last run.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+const (
+	dirName  = ".nada-cache"
+	fileName = "cache.json"
+)
+
+// analyzerVersion is stamped into every entry Store writes, so upgrading the
+// nada binary invalidates cached results even when a file's content and the
+// registered ruleset haven't changed (a rule's Check logic can change
+// without its ID changing). Left at "" for dev builds that never call
+// SetAnalyzerVersion, which behaves the same as before this field existed.
+var analyzerVersion string
+
+// SetAnalyzerVersion records the running binary's version for cache
+// invalidation. Call it once at startup, the same way cli.SetVersionInfo
+// records the version shown by `nada version`.
+func SetAnalyzerVersion(v string) {
+	analyzerVersion = v
+}
+
+// Entry is what gets persisted per analyzed file.
+type Entry struct {
+	ContentHash          string                  `json:"content_hash"`
+	RulesetHash          string                  `json:"ruleset_hash"`
+	AnalyzerVersion      string                  `json:"analyzer_version,omitempty"`
+	GoVersion            string                  `json:"go_version"`
+	Issues               []types.Issue           `json:"issues"`
+	LinesOfCode          int                     `json:"lines_of_code"`
+	CyclomaticComplexity int                     `json:"cyclomatic_complexity"`
+	CognitiveComplexity  int                     `json:"cognitive_complexity"`
+	HasTestFunc          bool                    `json:"has_test_func"`
+	PerFunction          []types.FunctionMetrics `json:"per_function,omitempty"`
+
+	// LastUsed is when this entry was last written or read back on a hit,
+	// in UnixNano. It's the recency signal evictLRU sorts on when the cache
+	// exceeds its size budget (see Cache.maxBytes).
+	LastUsed int64 `json:"last_used"`
+}
+
+// Cache is a persistent, file-hash-keyed store of per-file analysis
+// results, rooted at <projectPath>/.nada-cache. Lookup and Store are safe
+// to call concurrently (e.g. one call per analyzeFilesParallel worker
+// goroutine); a mutex guards the underlying map since Go maps themselves
+// aren't.
+type Cache struct {
+	mu       sync.RWMutex
+	path     string
+	entries  map[string]Entry
+	disabled bool
+	maxBytes int64
+}
+
+// Open loads (or initializes) the cache rooted at projectPath. A missing or
+// corrupt cache file is treated as an empty cache rather than an error,
+// since the cache is a pure optimization - analysis is always correct
+// without it, just slower.
+func Open(projectPath string) *Cache {
+	path := filepath.Join(projectPath, dirName, fileName)
+	c := &Cache{path: path, entries: make(map[string]Entry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		c.entries = make(map[string]Entry)
+	}
+
+	return c
+}
+
+// SetMaxBytes sets the on-disk size budget driving evictLRU, the --cache-size
+// flag's effect: Save drops least-recently-used entries, oldest first, until
+// the cache fits within n bytes. n <= 0 means unbounded, the default a fresh
+// Cache starts with.
+func (c *Cache) SetMaxBytes(n int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxBytes = n
+}
+
+// Noop returns a cache that never hits and never persists, for --no-cache
+// runs that want the analyzer's normal code path (Lookup/Store/Save calls
+// still happen) without reading or writing .nada-cache at all.
+func Noop() *Cache {
+	return &Cache{entries: make(map[string]Entry), disabled: true}
+}
+
+// Lookup returns the cached entry for filePath if its content hash and
+// ruleset hash match what's stored and it was produced by the same Go
+// toolchain and nada build, so the caller knows the cached result is still
+// valid.
+func (c *Cache) Lookup(filePath, contentHash, rulesetHash string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.disabled {
+		return Entry{}, false
+	}
+
+	entry, ok := c.entries[filePath]
+	if !ok {
+		return Entry{}, false
+	}
+	if entry.ContentHash != contentHash || entry.RulesetHash != rulesetHash ||
+		entry.GoVersion != runtime.Version() || entry.AnalyzerVersion != analyzerVersion {
+		return Entry{}, false
+	}
+
+	entry.LastUsed = time.Now().UnixNano()
+	c.entries[filePath] = entry
+	return entry, true
+}
+
+// Store records filePath's analysis result for reuse by a later run.
+func (c *Cache) Store(filePath string, entry Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.disabled {
+		return
+	}
+
+	entry.GoVersion = runtime.Version()
+	entry.AnalyzerVersion = analyzerVersion
+	entry.LastUsed = time.Now().UnixNano()
+	c.entries[filePath] = entry
+}
+
+// Save persists the cache to disk, creating the .nada-cache directory if
+// needed. It is a no-op for a Noop cache, so a --no-cache run never writes
+// .nada-cache even if something upstream still calls Save.
+func (c *Cache) Save() error {
+	if c.disabled {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.evictLRU()
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, data, 0644)
+}
+
+// evictLRU drops least-recently-used entries, oldest LastUsed first, until
+// the cache's marshaled size fits within c.maxBytes. A no-op when maxBytes
+// is unset (<= 0), the default. Callers must hold c.mu for writing.
+func (c *Cache) evictLRU() {
+	if c.maxBytes <= 0 || len(c.entries) == 0 {
+		return
+	}
+
+	type sized struct {
+		path string
+		size int64
+		last int64
+	}
+	sizes := make([]sized, 0, len(c.entries))
+	var total int64
+	for path, entry := range c.entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		size := int64(len(data))
+		sizes = append(sizes, sized{path: path, size: size, last: entry.LastUsed})
+		total += size
+	}
+	if total <= c.maxBytes {
+		return
+	}
+
+	sort.Slice(sizes, func(i, j int) bool { return sizes[i].last < sizes[j].last })
+	for _, s := range sizes {
+		if total <= c.maxBytes {
+			break
+		}
+		delete(c.entries, s.path)
+		total -= s.size
+	}
+}
+
+// Prune drops entries for files that no longer exist on disk (deleted or
+// renamed since they were cached), so the cache doesn't grow unbounded over
+// a project's lifetime. It returns the number of entries removed; the
+// caller is responsible for calling Save to persist the result.
+func (c *Cache) Prune() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for filePath := range c.entries {
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			delete(c.entries, filePath)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Clear discards every cached entry and removes the on-disk cache file.
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	c.entries = make(map[string]Entry)
+	c.mu.Unlock()
+
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Len returns the number of entries currently in the cache.
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Path returns the on-disk path backing this cache, for `nada cache status`
+// to report where a project's cache lives.
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// Size returns the size in bytes of the on-disk cache file, or 0 if it
+// doesn't exist yet.
+func (c *Cache) Size() int64 {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// HashContent returns the stable content hash used as a cache validity key.
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashRuleset returns a stable hash identifying the exact set of rule IDs in
+// use, so the whole cache is invalidated when rules are added, removed, or
+// reordered.
+func HashRuleset(ruleIDs []string) string {
+	sorted := append([]string(nil), ruleIDs...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	for _, id := range sorted {
+		h.Write([]byte(id))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
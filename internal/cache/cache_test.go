@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func TestCacheStoreAndLookup(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+
+	contentHash := HashContent([]byte("package main"))
+	rulesetHash := HashRuleset([]string{"structure", "security"})
+
+	if _, ok := c.Lookup("main.go", contentHash, rulesetHash); ok {
+		t.Fatal("Lookup() found an entry in a fresh cache")
+	}
+
+	c.Store("main.go", Entry{
+		ContentHash: contentHash,
+		RulesetHash: rulesetHash,
+		Issues:      []types.Issue{{Rule: "todo_comment"}},
+		LinesOfCode: 10,
+	})
+
+	entry, ok := c.Lookup("main.go", contentHash, rulesetHash)
+	if !ok {
+		t.Fatal("Lookup() did not find the just-stored entry")
+	}
+	if entry.LinesOfCode != 10 {
+		t.Errorf("Lookup().LinesOfCode = %d, want 10", entry.LinesOfCode)
+	}
+}
+
+func TestCacheLookupMissesOnChangedContent(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+	rulesetHash := HashRuleset([]string{"structure"})
+
+	c.Store("main.go", Entry{ContentHash: HashContent([]byte("v1")), RulesetHash: rulesetHash})
+
+	if _, ok := c.Lookup("main.go", HashContent([]byte("v2")), rulesetHash); ok {
+		t.Error("Lookup() hit despite changed file content")
+	}
+}
+
+func TestCacheLookupMissesOnChangedRuleset(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+	contentHash := HashContent([]byte("v1"))
+
+	c.Store("main.go", Entry{ContentHash: contentHash, RulesetHash: HashRuleset([]string{"structure"})})
+
+	if _, ok := c.Lookup("main.go", contentHash, HashRuleset([]string{"structure", "security"})); ok {
+		t.Error("Lookup() hit despite changed ruleset")
+	}
+}
+
+func TestCacheSaveAndReopen(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+
+	contentHash := HashContent([]byte("package main"))
+	rulesetHash := HashRuleset([]string{"structure"})
+	c.Store("main.go", Entry{ContentHash: contentHash, RulesetHash: rulesetHash, LinesOfCode: 5})
+
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	if _, err := filepath.Abs(filepath.Join(dir, dirName, fileName)); err != nil {
+		t.Fatalf("failed to resolve cache file path: %v", err)
+	}
+
+	reopened := Open(dir)
+	entry, ok := reopened.Lookup("main.go", contentHash, rulesetHash)
+	if !ok {
+		t.Fatal("Lookup() on reopened cache did not find the saved entry")
+	}
+	if entry.LinesOfCode != 5 {
+		t.Errorf("reopened entry.LinesOfCode = %d, want 5", entry.LinesOfCode)
+	}
+}
+
+func TestOpenMissingCacheIsEmpty(t *testing.T) {
+	c := Open(t.TempDir())
+	if _, ok := c.Lookup("main.go", "x", "y"); ok {
+		t.Error("Lookup() hit on a cache that was never saved")
+	}
+}
+
+func TestHashRulesetOrderIndependent(t *testing.T) {
+	a := HashRuleset([]string{"structure", "security"})
+	b := HashRuleset([]string{"security", "structure"})
+	if a != b {
+		t.Errorf("HashRuleset() is order-dependent: %v != %v", a, b)
+	}
+}
+
+func TestCacheLookupMissesOnChangedAnalyzerVersion(t *testing.T) {
+	defer SetAnalyzerVersion(analyzerVersion)
+
+	dir := t.TempDir()
+	c := Open(dir)
+	contentHash := HashContent([]byte("package main"))
+	rulesetHash := HashRuleset([]string{"structure"})
+
+	SetAnalyzerVersion("v1.0.0")
+	c.Store("main.go", Entry{ContentHash: contentHash, RulesetHash: rulesetHash})
+
+	SetAnalyzerVersion("v1.1.0")
+	if _, ok := c.Lookup("main.go", contentHash, rulesetHash); ok {
+		t.Error("Lookup() hit despite changed analyzer version")
+	}
+}
+
+func TestCachePruneRemovesEntriesForDeletedFiles(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+
+	keptPath := filepath.Join(dir, "kept.go")
+	if err := os.WriteFile(keptPath, []byte("package main"), 0644); err != nil {
+		t.Fatalf("failed to write kept.go: %v", err)
+	}
+	deletedPath := filepath.Join(dir, "deleted.go")
+
+	c.Store(keptPath, Entry{ContentHash: "a"})
+	c.Store(deletedPath, Entry{ContentHash: "b"})
+
+	if removed := c.Prune(); removed != 1 {
+		t.Fatalf("Prune() removed %d entries, want 1", removed)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d after Prune(), want 1", c.Len())
+	}
+	if _, ok := c.Lookup(keptPath, "a", ""); !ok {
+		t.Error("Prune() removed the entry for a file that still exists")
+	}
+}
+
+func TestCacheSaveEvictsLeastRecentlyUsedOverBudget(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+
+	c.Store("old.go", Entry{ContentHash: "a", Issues: []types.Issue{{Rule: "todo_comment", Message: "old"}}})
+	time.Sleep(time.Millisecond)
+	c.Store("new.go", Entry{ContentHash: "b", Issues: []types.Issue{{Rule: "todo_comment", Message: "new"}}})
+
+	unbounded, err := json.Marshal(c.entries)
+	if err != nil {
+		t.Fatalf("marshaling cache: %v", err)
+	}
+	c.SetMaxBytes(int64(len(unbounded)) / 2)
+
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	if c.Len() != 1 {
+		t.Fatalf("Len() after Save() = %d, want 1 (budget forces eviction down to one entry)", c.Len())
+	}
+	if _, ok := c.Lookup("new.go", "b", ""); !ok {
+		t.Error("Save() evicted new.go, want it to keep the more recently used entry")
+	}
+	if _, ok := c.Lookup("old.go", "a", ""); ok {
+		t.Error("Save() kept old.go, want the least-recently-used entry evicted")
+	}
+}
+
+func TestCacheSetMaxBytesZeroIsUnbounded(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+	c.Store("a.go", Entry{ContentHash: "a"})
+	c.Store("b.go", Entry{ContentHash: "b"})
+
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() after Save() with no budget set = %d, want 2", c.Len())
+	}
+}
+
+func TestCacheClearEmptiesEntriesAndRemovesFile(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+	c.Store("main.go", Entry{ContentHash: "a"})
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear() failed: %v", err)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() = %d after Clear(), want 0", c.Len())
+	}
+	if _, err := os.Stat(filepath.Join(dir, dirName, fileName)); !os.IsNotExist(err) {
+		t.Errorf("cache file still exists after Clear(): err = %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chaksack/nada/internal/analyzer"
+	"github.com/chaksack/nada/internal/ignore"
+	"github.com/chaksack/nada/internal/types"
+)
+
+// baselineCmd groups subcommands for managing the .nadaignore checksum
+// baseline (see internal/ignore), as opposed to `analyze`'s --baseline/
+// --update-baseline flags, which diff against a fingerprinted JSON report.
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the .nadaignore checksum baseline",
+}
+
+// baselineCreateCmd represents `nada baseline create`
+var baselineCreateCmd = &cobra.Command{
+	Use:   "create [path]",
+	Short: "Capture the project's current issues into a new .nadaignore",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBaselineWrite(cmd, args, false)
+	},
+}
+
+// baselineUpdateCmd represents `nada baseline update`
+var baselineUpdateCmd = &cobra.Command{
+	Use:   "update [path]",
+	Short: "Regenerate .nadaignore from the project's current issues",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBaselineWrite(cmd, args, true)
+	},
+}
+
+// runBaselineWrite analyzes the project at args[0] (default ".") with no
+// rule filtering and writes every issue it finds into a .nadaignore. With
+// overwrite false (`create`), an existing baseline at the target path is
+// left untouched so a second run can't silently discard it.
+func runBaselineWrite(cmd *cobra.Command, args []string, overwrite bool) error {
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+
+	ignoreFilePath, _ := cmd.Flags().GetString("ignore-file")
+	if ignoreFilePath == "" {
+		ignoreFilePath = filepath.Join(projectPath, ignore.DefaultFileName)
+	}
+
+	if !overwrite {
+		if _, err := os.Stat(ignoreFilePath); err == nil {
+			return fmt.Errorf("%s already exists; use `nada baseline update` to regenerate it", ignoreFilePath)
+		}
+	}
+
+	codeAnalyzer := analyzer.New(types.AnalysisOptions{ProjectPath: projectPath})
+	report, err := codeAnalyzer.AnalyzeProject()
+	if err != nil {
+		return fmt.Errorf("analysis failed: %w", err)
+	}
+
+	baseline, err := ignore.BuildFromReport(report)
+	if err != nil {
+		return fmt.Errorf("failed to build baseline: %w", err)
+	}
+
+	if err := ignore.Save(baseline, ignoreFilePath); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "📐 wrote baseline for %d file(s) to %s\n", len(baseline.Entries), ignoreFilePath)
+	return nil
+}
+
+func init() {
+	baselineCreateCmd.Flags().String("ignore-file", "", "Baseline file path (default: <path>/.nadaignore)")
+	baselineUpdateCmd.Flags().String("ignore-file", "", "Baseline file path (default: <path>/.nadaignore)")
+
+	baselineCmd.AddCommand(baselineCreateCmd)
+	baselineCmd.AddCommand(baselineUpdateCmd)
+	rootCmd.AddCommand(baselineCmd)
+}
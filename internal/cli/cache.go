@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chaksack/nada/internal/cache"
+)
+
+// cacheCmd groups subcommands for inspecting and discarding the incremental
+// analysis cache at <path>/.nada-cache, which `analyze` maintains
+// automatically (see internal/cache and CodeAnalyzer.analyzeFileConcurrent).
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect or discard the incremental analysis cache",
+}
+
+// cachePruneCmd represents `nada cache prune`
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune [path]",
+	Short: "Drop cache entries for files that no longer exist on disk",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runCachePrune,
+}
+
+// cacheClearCmd represents `nada cache clear`
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear [path]",
+	Short: "Discard the entire cache",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runCacheClear,
+}
+
+// cacheStatusCmd represents `nada cache status`
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status [path]",
+	Short: "Show the cache file location, entry count, and on-disk size",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runCacheStatus,
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+
+	c := cache.Open(projectPath)
+	removed := c.Prune()
+	if err := c.Save(); err != nil {
+		return fmt.Errorf("failed to save pruned cache: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "🧹 pruned %d stale entries, %d remaining\n", removed, c.Len())
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+
+	c := cache.Open(projectPath)
+	if err := c.Clear(); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "🧹 cache cleared")
+	return nil
+}
+
+func runCacheStatus(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+
+	c := cache.Open(projectPath)
+	fmt.Fprintf(cmd.OutOrStdout(), "📦 cache file: %s\n", c.Path())
+	fmt.Fprintf(cmd.OutOrStdout(), "   entries: %d\n", c.Len())
+	fmt.Fprintf(cmd.OutOrStdout(), "   size: %d bytes\n", c.Size())
+	return nil
+}
+
+func init() {
+	cacheCmd.AddCommand(cachePruneCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cacheStatusCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chaksack/nada/internal/fix"
+	"github.com/chaksack/nada/internal/types"
+)
+
+// runFixes applies the issues' suggested fixes per --fix/--fix-dry-run and
+// prints a summary: a bare --fix applies only mechanical, non-semantic
+// fixes, --fix=unsafe also applies fixes a rule marked unsafe (e.g. an
+// identifier rename), and --fix-dry-run previews the change as a unified
+// diff without writing anything.
+func runFixes(cmd *cobra.Command, issues []types.Issue, fixMode string, dryRun bool) error {
+	fixer := fix.New(fixMode == "unsafe")
+	fixer.DryRun = dryRun
+
+	results, err := fixer.Apply(issues)
+	if err != nil {
+		return fmt.Errorf("applying fixes: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(results) == 0 {
+		fmt.Fprintln(out, "🔧 No fixable issues found")
+		return nil
+	}
+
+	verb := "Applied"
+	if dryRun {
+		verb = "Would apply"
+	}
+	fmt.Fprintf(out, "\n🔧 %s fixes to %d file(s):\n", verb, len(results))
+	for _, result := range results {
+		if result.RolledBack {
+			fmt.Fprintf(out, "   ⚠️  %s: fix produced invalid Go source, rolled back\n", result.File)
+			continue
+		}
+		if result.Err != nil {
+			fmt.Fprintf(out, "   ❌ %s: %v\n", result.File, result.Err)
+			continue
+		}
+		fmt.Fprintf(out, "   %s: %d edit(s)\n", result.File, result.Applied)
+		for _, skip := range result.Skipped {
+			fmt.Fprintf(out, "      ⏭  skipped: %s\n", skip)
+		}
+		if dryRun && result.Diff != "" {
+			fmt.Fprint(out, result.Diff)
+		}
+	}
+	return nil
+}
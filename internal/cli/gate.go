@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chaksack/nada/internal/analyzer"
+	"github.com/chaksack/nada/internal/gate"
+	"github.com/chaksack/nada/internal/types"
+)
+
+// gateCmd groups subcommands for evaluating internal/gate quality-gate
+// definitions, as opposed to `analyze`'s hardcoded grade/severity/score
+// checks (see reporter.printQualityGates).
+var gateCmd = &cobra.Command{
+	Use:   "gate",
+	Short: "Evaluate a quality-gate definition against a project",
+}
+
+// gateCheckCmd represents `nada gate check`
+var gateCheckCmd = &cobra.Command{
+	Use:   "check [path]",
+	Short: "Analyze a project and check it against a gate file, exiting non-zero on failure",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runGateCheck,
+}
+
+func runGateCheck(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+
+	gateFile, _ := cmd.Flags().GetString("gate")
+	if gateFile == "" {
+		return fmt.Errorf("--gate is required")
+	}
+	g, err := gate.Load(gateFile)
+	if err != nil {
+		return err
+	}
+
+	baselineFile, _ := cmd.Flags().GetString("baseline")
+	var baseline *types.Report
+	if baselineFile != "" {
+		baseline, err = loadReport(baselineFile)
+		if err != nil {
+			return fmt.Errorf("failed to load baseline: %w", err)
+		}
+	}
+
+	report, err := analyzer.New(types.AnalysisOptions{ProjectPath: projectPath}).AnalyzeProject()
+	if err != nil {
+		return fmt.Errorf("analysis failed: %w", err)
+	}
+
+	results := gate.Evaluate(g, report, baseline)
+	failed := printGateResults(cmd, results)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d gate condition(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// loadReport reads a previously saved JSON report, the same format
+// CodeAnalyzer.LoadBaseline reads for `analyze --baseline`.
+func loadReport(path string) (*types.Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report types.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// printGateResults prints one line per condition and returns how many failed.
+func printGateResults(cmd *cobra.Command, results []types.QualityGate) int {
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, "\n🚪 Quality Gate Results:")
+
+	failed := 0
+	for _, result := range results {
+		status := "❌ FAILED"
+		if result.Passed {
+			status = "✅ PASSED"
+		} else {
+			failed++
+		}
+		fmt.Fprintf(out, "   %s %s (%s): %s\n", status, result.Condition, result.Threshold, result.Message)
+	}
+	return failed
+}
+
+func init() {
+	gateCheckCmd.Flags().String("gate", "", "Gate definition YAML file (required)")
+	gateCheckCmd.Flags().String("baseline", "", "Baseline report file; \"new_*\" metrics count only issues introduced since it")
+
+	gateCmd.AddCommand(gateCheckCmd)
+	rootCmd.AddCommand(gateCmd)
+}
@@ -1,16 +1,22 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/chaksack/nada/internal/analyzer"
+	"github.com/chaksack/nada/internal/cache"
+	"github.com/chaksack/nada/internal/config"
+	"github.com/chaksack/nada/internal/gate"
+	"github.com/chaksack/nada/internal/ignore"
 	"github.com/chaksack/nada/internal/reporter"
+	"github.com/chaksack/nada/internal/rules"
 	"github.com/chaksack/nada/internal/types"
+	"github.com/chaksack/nada/internal/vulndb"
 )
 
 var (
@@ -24,6 +30,7 @@ func SetVersionInfo(v, bt, c string) {
 	version = v
 	buildTime = bt
 	commit = c
+	cache.SetAnalyzerVersion(v)
 }
 
 // rootCmd represents the base command
@@ -69,41 +76,271 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	coverageFile, _ := cmd.Flags().GetString("coverage")
 	diffTarget, _ := cmd.Flags().GetString("diff")
 	configFile, _ := cmd.Flags().GetString("config")
+	outputFormats, _ := cmd.Flags().GetStringSlice("format")
+	if len(outputFormats) == 0 {
+		outputFormats = []string{types.FormatJSON}
+	}
+	baselineFile, _ := cmd.Flags().GetString("baseline")
+	updateBaseline, _ := cmd.Flags().GetBool("update-baseline")
 	verbose, _ := cmd.Flags().GetBool("verbose")
 	includeTests, _ := cmd.Flags().GetBool("include-tests")
 	excludeFiles, _ := cmd.Flags().GetStringSlice("exclude")
+	workers, _ := cmd.Flags().GetInt("workers")
+	if cmd.Flags().Changed("jobs") {
+		workers, _ = cmd.Flags().GetInt("jobs")
+	}
+	vulnDB, _ := cmd.Flags().GetBool("vulndb")
+	localVulnDBRepo, _ := cmd.Flags().GetString("local-vulndb-repo")
+	offlineVulnDB, _ := cmd.Flags().GetBool("offline")
+	vulnDBURL, _ := cmd.Flags().GetString("vuln-db")
+	regoBundleDir, _ := cmd.Flags().GetString("rego-bundle")
+	progressFlag, _ := cmd.Flags().GetBool("progress")
+	ignoreFilePath, _ := cmd.Flags().GetString("ignore-file")
+	failOnNew, _ := cmd.Flags().GetBool("fail-on-new")
+	since, _ := cmd.Flags().GetString("since")
+	diffBase, _ := cmd.Flags().GetString("diff-base")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	cacheMaxBytes, _ := cmd.Flags().GetInt64("cache-size")
+	gateConfigFile, _ := cmd.Flags().GetString("gate-config")
+	gateFailOn, _ := cmd.Flags().GetStringSlice("gate-fail-on")
+	linters, _ := cmd.Flags().GetStringSlice("linters")
+
+	// Load the config file before building AnalysisOptions: its "linters"
+	// and "exclude_files" entries feed options fields that CodeAnalyzer
+	// copies by value at New(), so they have to win (or lose) against the
+	// matching flag before that happens, not after. The rule-engine side of
+	// cfg (custom rules, rule packs, thresholds) is applied further below,
+	// once codeAnalyzer exists.
+	var cfg *config.Config
+	if configFile != "" {
+		loaded, err := config.Load(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = loaded
+		if !cmd.Flags().Changed("linters") && len(cfg.Linters) > 0 {
+			linters = cfg.Linters
+		}
+		if !cmd.Flags().Changed("exclude") && len(cfg.ExcludeFiles) > 0 {
+			excludeFiles = cfg.ExcludeFiles
+		}
+	}
 
 	// Create analysis options
 	options := types.AnalysisOptions{
-		ProjectPath:  projectPath,
-		OutputFile:   outputFile,
-		CoverageFile: coverageFile,
-		DiffTarget:   diffTarget,
-		ConfigFile:   configFile,
-		ExcludeFiles: excludeFiles,
-		IncludeTests: includeTests,
-		Verbose:      verbose,
+		ProjectPath:   projectPath,
+		OutputFile:    outputFile,
+		OutputFormat:  outputFormats[0],
+		CoverageFile:  coverageFile,
+		DiffTarget:    diffTarget,
+		ConfigFile:    configFile,
+		ExcludeFiles:  excludeFiles,
+		IncludeTests:  includeTests,
+		Verbose:       verbose,
+		Workers:       workers,
+		Since:         since,
+		DiffBase:      diffBase,
+		NoCache:       noCache,
+		CacheMaxBytes: cacheMaxBytes,
+		Linters:       linters,
 	}
 
 	// Create analyzer and run analysis
 	codeAnalyzer := analyzer.New(options)
+
+	if updateBaseline && baselineFile == "" {
+		return fmt.Errorf("--update-baseline requires --baseline <path>")
+	}
+
+	if baselineFile != "" && !updateBaseline {
+		if err := codeAnalyzer.LoadBaseline(baselineFile); err != nil {
+			return fmt.Errorf("failed to load baseline: %w", err)
+		}
+	}
+
+	if ignoreFilePath == "" {
+		ignoreFilePath = filepath.Join(projectPath, ignore.DefaultFileName)
+	}
+	if err := codeAnalyzer.LoadIgnoreFile(ignoreFilePath); err != nil {
+		return fmt.Errorf("failed to load %s: %w", ignoreFilePath, err)
+	}
+
+	if vulnDB {
+		codeAnalyzer.RuleEngine().RegisterRule(rules.NewVulnDBRule(vulnSourceFor(localVulnDBRepo, vulnDBURL, offlineVulnDB)))
+	}
+
+	rulePacksDir := filepath.Join(projectPath, "rules")
+	if cfg != nil {
+		for _, cr := range cfg.CustomRules {
+			codeAnalyzer.RuleEngine().RegisterRule(rules.NewRegoRule(cr.ID, cr.Path, cr.Severity, cr.Type, rules.NewCLIEvaluator()))
+		}
+		if cfg.RulePacksDir != "" {
+			rulePacksDir = filepath.Join(filepath.Dir(configFile), cfg.RulePacksDir)
+		}
+		applyRuleConfig(codeAnalyzer.RuleEngine(), cfg)
+	}
+
+	// Auto-discover a rules/ directory of YAML pattern rule packs at the
+	// project root, the same way linters like golangci-lint pick up custom
+	// rules without a flag per file.
+	packRules, err := rules.LoadRulePackDir(rulePacksDir)
+	if err != nil {
+		return fmt.Errorf("failed to load rule pack directory %s: %w", rulePacksDir, err)
+	}
+	for _, rule := range packRules {
+		codeAnalyzer.RuleEngine().RegisterRule(rule)
+	}
+
+	if regoBundleDir != "" {
+		if err := codeAnalyzer.RuleEngine().LoadRegoBundle(regoBundleDir); err != nil {
+			return fmt.Errorf("failed to load rego bundle %s: %w", regoBundleDir, err)
+		}
+	}
+
+	// --enable/--disable override the config file's enabled_rules/
+	// disabled_rules for this run only, the same "flag wins over file"
+	// precedence --gate-fail-on and --gate-config follow. Like
+	// applyRuleConfig, disabling wins when a rule ID appears in both.
+	enableRules, _ := cmd.Flags().GetStringSlice("enable")
+	disableRules, _ := cmd.Flags().GetStringSlice("disable")
+	for _, id := range enableRules {
+		codeAnalyzer.RuleEngine().SetEnabled(id, true)
+	}
+	for _, id := range disableRules {
+		codeAnalyzer.RuleEngine().SetEnabled(id, false)
+	}
+
+	// Show a live progress indicator unless --progress was explicitly set;
+	// default to auto-detecting an interactive terminal on stderr so piped
+	// CI logs don't get a redrawn status line.
+	showProgress := reporter.IsTerminal(os.Stderr)
+	if cmd.Flags().Changed("progress") {
+		showProgress = progressFlag
+	}
+
+	// --log-events writes one NDJSON line per lifecycle event to a file, so
+	// external tooling can tail a long monorepo run instead of waiting for
+	// the final report (see reporter.EventLog).
+	logEventsPath, _ := cmd.Flags().GetString("log-events")
+	var eventLog *reporter.EventLog
+	if logEventsPath != "" {
+		f, err := os.Create(logEventsPath)
+		if err != nil {
+			return fmt.Errorf("failed to create --log-events file: %w", err)
+		}
+		defer f.Close()
+		eventLog = reporter.NewEventLog(f)
+		eventLog.AnalysisStarted()
+	}
+
+	var progress *reporter.Progress
+	if showProgress || eventLog != nil {
+		w := io.Discard
+		tty := false
+		if showProgress {
+			w = os.Stderr
+			tty = reporter.IsTerminal(os.Stderr)
+		}
+		progress = reporter.NewProgressWithEventLog(w, tty, eventLog)
+		codeAnalyzer.SetProgress(progress.Events())
+	}
+
 	report, err := codeAnalyzer.AnalyzeProject()
+	if progress != nil {
+		progress.Close()
+	}
 	if err != nil {
+		if eventLog != nil {
+			eventLog.AnalyzerError(err)
+		}
 		return fmt.Errorf("analysis failed: %w", err)
 	}
+	if eventLog != nil {
+		eventLog.AnalysisFinished()
+	}
 
-	// Print report to console
-	reporter.PrintConsoleReport(report)
+	fixMode, _ := cmd.Flags().GetString("fix")
+	fixDryRun, _ := cmd.Flags().GetBool("fix-dry-run")
+	if fixMode != "" || fixDryRun {
+		if err := runFixes(cmd, report.Issues, fixMode, fixDryRun); err != nil {
+			return err
+		}
+	}
+
+	// Establishing a fresh baseline: persist this run's issues as the new
+	// baseline and skip gating, since there's nothing to ratchet against yet.
+	if updateBaseline {
+		if err := saveReport(report, baselineFile, types.FormatJSON, codeAnalyzer.RuleEngine()); err != nil {
+			return fmt.Errorf("failed to update baseline: %w", err)
+		}
+		fmt.Printf("📐 Baseline updated: %s\n", baselineFile)
+	} else if baseline := codeAnalyzer.Baseline(); baseline != nil {
+		// When a baseline is loaded, only newly introduced issues count
+		// towards quality gates so CI fails on regressions rather than
+		// legacy debt; report.Issues keeps every issue, tagged IsNew, so
+		// console output can still show carried-over findings.
+		var diff types.ReportDiff
+		report, diff = reporter.DiffAgainstBaseline(report, baseline)
+		if quiet, _ := cmd.Flags().GetBool("quiet"); !quiet {
+			reporter.PrintDiffReport(diff)
+		}
+	}
+
+	// Print report to console, unless --quiet asked for script-friendly
+	// output with none of the emoji summary (the saved-report path and exit
+	// code are still the contract a script relies on).
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	if !quiet {
+		reporter.PrintConsoleReport(report)
+	}
 
 	// Save report if requested
 	if outputFile != "" {
-		if err := saveReport(report, outputFile); err != nil {
+		saved, err := saveReports(report, outputFile, outputFormats, codeAnalyzer.RuleEngine())
+		if err != nil {
 			return fmt.Errorf("failed to save report: %w", err)
 		}
-		fmt.Printf("💾 Report saved to: %s\n", outputFile)
+		for _, path := range saved {
+			fmt.Printf("💾 Report saved to: %s\n", path)
+		}
+	}
+
+	// --gate-config/--gate-fail-on/the config file's "quality_gate" section
+	// all run a configurable internal/gate.Gate instead of the hardcoded
+	// checks below, and use exit code 2 so CI can tell a failed quality gate
+	// apart from exit code 1 (an analyzer error, returned above as err).
+	// They take over exit handling entirely when set; --fail-on-new and the
+	// hardcoded gate are for callers that haven't adopted a gate config yet.
+	hasQualityGateConfig := cfg != nil && !cfg.QualityGate.IsZero()
+	if gateConfigFile != "" || len(gateFailOn) > 0 || hasQualityGateConfig {
+		g, err := loadAnalyzeGate(gateConfigFile, gateFailOn)
+		if err != nil {
+			return err
+		}
+		if hasQualityGateConfig {
+			fileGate, err := cfg.QualityGate.ToGate()
+			if err != nil {
+				return fmt.Errorf("failed to apply quality_gate config: %w", err)
+			}
+			g.Conditions = append(g.Conditions, fileGate.Conditions...)
+		}
+		results := gate.Evaluate(g, report, codeAnalyzer.Baseline())
+		if failed := printGateResults(cmd, results); failed > 0 {
+			os.Exit(2)
+		}
+		return nil
 	}
 
-	// Exit with error code based on quality gates
+	// Exit with error code based on quality gates. --fail-on-new replaces the
+	// usual severity/grade gate entirely: once a .nadaignore baseline is in
+	// place, the only thing that should break a legacy build is an issue
+	// that isn't covered by it.
+	if failOnNew {
+		if shouldFailOnNew(report) {
+			os.Exit(1)
+		}
+		return nil
+	}
 	if shouldFailBuild(report) {
 		os.Exit(1)
 	}
@@ -111,6 +348,64 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// loadAnalyzeGate assembles the *gate.Gate backing --gate-config/--gate-fail-on:
+// conditions from the config file (if any) followed by the --gate-fail-on
+// shorthand conditions, so a CI pipeline can combine a checked-in threshold
+// file with an ad hoc "also fail on new vulnerabilities" flag.
+func loadAnalyzeGate(configFile string, failOn []string) (*gate.Gate, error) {
+	g := &gate.Gate{}
+	if configFile != "" {
+		fileGate, err := gate.Load(configFile)
+		if err != nil {
+			return nil, err
+		}
+		g.Conditions = append(g.Conditions, fileGate.Conditions...)
+	}
+	if len(failOn) > 0 {
+		failOnGate, err := gate.NewFailOnGate(failOn)
+		if err != nil {
+			return nil, err
+		}
+		g.Conditions = append(g.Conditions, failOnGate.Conditions...)
+	}
+	return g, nil
+}
+
+// shouldFailOnNew reports whether any issue survived .nadaignore
+// suppression, for --fail-on-new's onboarding gate: report.Issues only
+// contains issues AnalyzeProject didn't drop via the loaded ignore file
+// (see ignore.File.Apply), so any remainder is "new" relative to the
+// baseline, including ones flagged BaselineStale.
+func shouldFailOnNew(report *types.Report) bool {
+	return len(report.Issues) > 0
+}
+
+// vulnSourceFor builds the vulndb.Source backing --vulndb: a local repo
+// clone when localRepo is set, otherwise the public vuln.go.dev endpoint
+// (or endpointURL, when --vuln-db points at a mirror) cached under the
+// user's cache directory so repeat runs don't refetch modules they've
+// already seen. offline forces every lookup through that cache, failing
+// closed (no vulnerabilities reported) on a cache miss instead of reaching
+// the network - irrelevant when localRepo is set, since LocalSource never
+// touches the network either way.
+func vulnSourceFor(localRepo, endpointURL string, offline bool) vulndb.Source {
+	if localRepo != "" {
+		return vulndb.NewLocalSource(localRepo)
+	}
+
+	httpSource := vulndb.NewHTTPSource(endpointURL)
+	cacheDir, err := vulndb.DefaultCacheDir()
+	if err != nil {
+		if offline {
+			return vulndb.NewCachingSource(httpSource, "")
+		}
+		return httpSource
+	}
+	caching := vulndb.NewCachingSource(httpSource, cacheDir)
+	caching.Offline = offline
+	return caching
+}
+
 // runVersion shows version information
 func runVersion(cmd *cobra.Command, args []string) {
 	fmt.Fprintln(cmd.OutOrStdout(), "Nada", version)
@@ -119,23 +414,74 @@ func runVersion(cmd *cobra.Command, args []string) {
 	fmt.Fprintln(cmd.OutOrStdout(), "Author: Andrew Chakdahah (@chaksack)")
 }
 
-// saveReport saves the analysis report to a JSON file
-func saveReport(report *types.Report, filename string) error {
+// saveReports saves report in each of formats, returning the path each one
+// was written to. A single format writes directly to outputPath; multiple
+// formats require outputPath to be a directory (created if missing) and
+// write one file per format, named report.<ext>.
+func saveReports(report *types.Report, outputPath string, formats []string, engine *rules.Engine) ([]string, error) {
+	if len(formats) <= 1 {
+		format := types.FormatJSON
+		if len(formats) == 1 {
+			format = formats[0]
+		}
+		if err := saveReport(report, outputPath, format, engine); err != nil {
+			return nil, err
+		}
+		return []string{outputPath}, nil
+	}
+
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var saved []string
+	for _, format := range formats {
+		filename := filepath.Join(outputPath, "report."+formatExtension(format))
+		if err := saveReport(report, filename, format, engine); err != nil {
+			return nil, err
+		}
+		saved = append(saved, filename)
+	}
+	return saved, nil
+}
+
+// formatExtension picks a filename extension for a report format, used when
+// --format names several formats and each needs a distinct file in the
+// --output directory.
+func formatExtension(format string) string {
+	switch format {
+	case types.FormatSARIF:
+		return "sarif.json"
+	case types.FormatJUnit:
+		return "junit.xml"
+	case types.FormatCodeClimate:
+		return "codeclimate.json"
+	case types.FormatSonarQube:
+		return "sonarqube.json"
+	case types.FormatMarkdown:
+		return "md"
+	default:
+		return "json"
+	}
+}
+
+// saveReport saves the analysis report to filename in the requested format
+// (json, sarif, junit, codeclimate, sonarqube, markdown; defaults to json).
+func saveReport(report *types.Report, filename, format string, engine *rules.Engine) error {
 	// Ensure directory exists
 	dir := filepath.Dir(filename)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Marshal report to JSON
-	data, err := json.MarshalIndent(report, "", "  ")
+	file, err := os.Create(filename)
 	if err != nil {
-		return fmt.Errorf("failed to marshal report: %w", err)
+		return fmt.Errorf("failed to create file: %w", err)
 	}
+	defer file.Close()
 
-	// Write to file
-	if err := os.WriteFile(filename, data, 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	if err := reporter.WriteReport(report, engine, format, file); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
 	}
 
 	return nil
@@ -175,10 +521,36 @@ func init() {
 	analyzeCmd.Flags().StringP("output", "o", "", "Output file for JSON report")
 	analyzeCmd.Flags().StringP("coverage", "c", "", "Coverage profile file")
 	analyzeCmd.Flags().StringP("diff", "d", "", "Analyze only changes (staged, unstaged, HEAD, branch)")
-	analyzeCmd.Flags().StringP("config", "", "", "Configuration file path")
+	analyzeCmd.Flags().StringP("config", "", "", "Configuration file path (.nada.yaml or JSON; custom_rules, rule_packs_dir, enabled_rules/disabled_rules, thresholds, linters, exclude_files, rules.complexity, quality_gate)")
+	analyzeCmd.Flags().StringSliceP("format", "f", []string{types.FormatJSON}, "Output format(s) for --output: json, sarif, junit, codeclimate, sonarqube, markdown. Repeatable or comma-separated; with more than one, --output must be a directory")
+	analyzeCmd.Flags().StringP("baseline", "b", "", "Baseline report file; only issues introduced since it will fail quality gates")
+	analyzeCmd.Flags().Bool("update-baseline", false, "Write this run's issues to --baseline instead of diffing against it")
+	analyzeCmd.Flags().Bool("progress", false, "Show a live per-file progress indicator on stderr (default: auto-enabled when stderr is a terminal)")
 	analyzeCmd.Flags().BoolP("verbose", "v", false, "Verbose output")
 	analyzeCmd.Flags().BoolP("include-tests", "t", false, "Include test files in analysis")
 	analyzeCmd.Flags().StringSliceP("exclude", "e", []string{}, "Exclude file patterns")
+	analyzeCmd.Flags().IntP("workers", "w", 0, "Number of parallel analysis workers (default: number of CPUs)")
+	analyzeCmd.Flags().IntP("jobs", "j", 0, "Alias for --workers, for familiarity with tools like make -j/ripgrep -j")
+	analyzeCmd.Flags().Bool("vulndb", false, "Check dependencies against the Go vulnerability database (network access, unless --local-vulndb-repo is set)")
+	analyzeCmd.Flags().String("local-vulndb-repo", "", "Path to a local clone of https://github.com/golang/vulndb for offline --vulndb scanning (an air-gapped mirror path; implies --offline)")
+	analyzeCmd.Flags().Bool("offline", false, "With --vulndb, only consult the on-disk vulnerability cache; never query vuln.go.dev")
+	analyzeCmd.Flags().String("vuln-db", "", "With --vulndb, query this OSV-format mirror instead of https://vuln.go.dev")
+	analyzeCmd.Flags().String("rego-bundle", "", "Directory of *.rego policies to load as additional rules, one per file (see also config's custom_rules)")
+	analyzeCmd.Flags().String("ignore-file", "", "Checksum baseline file suppressing known issues (default: <path>/.nadaignore)")
+	analyzeCmd.Flags().Bool("fail-on-new", false, "Exit non-zero only when issues survive .nadaignore suppression, ignoring severity/grade gates")
+	analyzeCmd.Flags().String("since", "", "Only analyze files changed relative to this git ref (plus their package siblings); also accepts \"staged\"/\"unstaged\"")
+	analyzeCmd.Flags().String("diff-base", "", "Like --since, but also drop issues outside lines changed relative to this git ref (e.g. origin/main); lets legacy codebases adopt nada without a flag day")
+	analyzeCmd.Flags().Bool("no-cache", false, "Disable the incremental analysis cache; re-analyze every file and leave .nada-cache untouched")
+	analyzeCmd.Flags().Int64("cache-size", 0, "Bound .nada-cache to this many bytes, evicting least-recently-used entries on save (default: unbounded)")
+	analyzeCmd.Flags().String("fix", "", "Apply rules' suggested fixes to source files after analysis; bare --fix applies safe fixes only, --fix=unsafe also applies fixes a rule marked unsafe (e.g. an identifier rename)")
+	analyzeCmd.Flags().Lookup("fix").NoOptDefVal = "safe"
+	analyzeCmd.Flags().Bool("fix-dry-run", false, "Preview --fix's changes as a unified diff instead of writing them")
+	analyzeCmd.Flags().StringSlice("enable", nil, "Enable rule IDs for this run, overriding the config file's disabled_rules (e.g. --enable=ineffassign,gosec)")
+	analyzeCmd.Flags().StringSlice("disable", nil, "Disable rule IDs for this run, overriding the config file's enabled_rules (e.g. --disable=todo_comment)")
+	analyzeCmd.Flags().String("log-events", "", "Write one NDJSON line per lifecycle event (analysis_started, file_analyzed, issue_found, analyzer_error, analysis_finished) to this file")
+	analyzeCmd.Flags().String("gate-config", "", "internal/gate quality-gate YAML file; failing conditions exit 2 instead of the hardcoded severity/grade gate")
+	analyzeCmd.Flags().StringSlice("gate-fail-on", nil, "Shorthand --gate-config: comma-separated issue types/severities (high,medium,low,vulnerability,bug) that must have zero occurrences")
+	analyzeCmd.Flags().StringSlice("linters", nil, "External linters to shell out to and merge into the report: govet, staticcheck, gosec, errcheck, revive")
 
 	// Global flags
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress output")
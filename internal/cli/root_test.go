@@ -82,7 +82,7 @@ func TestSaveReport(t *testing.T) {
 	reportFile := filepath.Join(tmpDir, "test-report.json")
 
 	// Save report
-	err := saveReport(report, reportFile)
+	err := saveReport(report, reportFile, types.FormatJSON, nil)
 	if err != nil {
 		t.Fatalf("saveReport() failed: %v", err)
 	}
@@ -126,7 +126,7 @@ func TestSaveReportWithNestedDirectory(t *testing.T) {
 	// Test nested directory creation
 	reportFile := filepath.Join(tmpDir, "reports", "nested", "test-report.json")
 
-	err := saveReport(report, reportFile)
+	err := saveReport(report, reportFile, types.FormatJSON, nil)
 	if err != nil {
 		t.Fatalf("saveReport() with nested directory failed: %v", err)
 	}
@@ -335,8 +335,6 @@ func TestCommandInitialization(t *testing.T) {
 	}
 }
 
-
-
 // Helper function to create test files
 func createTestProject(t *testing.T, files map[string]string) string {
 	tmpDir := t.TempDir()
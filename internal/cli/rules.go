@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chaksack/nada/internal/config"
+	"github.com/chaksack/nada/internal/rules"
+)
+
+// rulesCmd groups subcommands for inspecting and tuning the rule catalog
+// analyze runs, independent of actually running an analysis.
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Inspect and configure nada's rule catalog",
+}
+
+// rulesListCmd represents `nada rules list`
+var rulesListCmd = &cobra.Command{
+	Use:   "list [path]",
+	Short: "List the rules that would run against a project",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runRulesList,
+}
+
+// rulesEnableCmd represents `nada rules enable`
+var rulesEnableCmd = &cobra.Command{
+	Use:   "enable <rule-id>",
+	Short: "Enable a rule in the config file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRulesToggle(cmd, args[0], true)
+	},
+}
+
+// rulesDisableCmd represents `nada rules disable`
+var rulesDisableCmd = &cobra.Command{
+	Use:   "disable <rule-id>",
+	Short: "Disable a rule in the config file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRulesToggle(cmd, args[0], false)
+	},
+}
+
+func runRulesList(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+
+	engine := rules.NewEngine()
+
+	configFile, _ := cmd.Flags().GetString("config")
+	rulePacksDir := filepath.Join(projectPath, "rules")
+	if configFile != "" {
+		cfg, err := config.Load(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if cfg.RulePacksDir != "" {
+			rulePacksDir = filepath.Join(filepath.Dir(configFile), cfg.RulePacksDir)
+		}
+		applyRuleConfig(engine, cfg)
+	}
+
+	packRules, err := rules.LoadRulePackDir(rulePacksDir)
+	if err != nil {
+		return fmt.Errorf("failed to load rule pack directory %s: %w", rulePacksDir, err)
+	}
+	for _, rule := range packRules {
+		engine.RegisterRule(rule)
+	}
+
+	registered := engine.GetRules()
+	sort.Slice(registered, func(i, j int) bool { return registered[i].ID() < registered[j].ID() })
+
+	w := cmd.OutOrStdout()
+	for _, rule := range registered {
+		status := "enabled"
+		if !engine.IsEnabled(rule.ID()) {
+			status = "disabled"
+		}
+		fmt.Fprintf(w, "%-8s %-24s %s\n", status, rule.ID(), rule.Description())
+	}
+
+	return nil
+}
+
+func runRulesToggle(cmd *cobra.Command, ruleID string, enable bool) error {
+	configFile, _ := cmd.Flags().GetString("config")
+	if configFile == "" {
+		configFile = ".nada.yaml"
+	}
+
+	cfg := &config.Config{}
+	if _, err := os.Stat(configFile); err == nil {
+		loaded, err := config.Load(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = loaded
+	}
+
+	cfg.DisabledRules = removeString(cfg.DisabledRules, ruleID)
+	cfg.EnabledRules = removeString(cfg.EnabledRules, ruleID)
+	if enable {
+		cfg.EnabledRules = append(cfg.EnabledRules, ruleID)
+	} else {
+		cfg.DisabledRules = append(cfg.DisabledRules, ruleID)
+	}
+
+	if err := config.SaveYAML(cfg, configFile); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	verb := "enabled"
+	if !enable {
+		verb = "disabled"
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s rule %q in %s\n", verb, ruleID, configFile)
+	return nil
+}
+
+// applyRuleConfig applies cfg's enabled_rules/disabled_rules and thresholds
+// to engine: DisabledRules wins over EnabledRules, and a threshold for
+// "complexity" or "structure" replaces the default rule instance with one
+// tuned to that limit.
+func applyRuleConfig(engine *rules.Engine, cfg *config.Config) {
+	for _, id := range cfg.EnabledRules {
+		engine.SetEnabled(id, true)
+	}
+	for _, id := range cfg.DisabledRules {
+		engine.SetEnabled(id, false)
+	}
+
+	if threshold, ok := cfg.Thresholds["complexity"]; ok {
+		engine.ReplaceRule(rules.NewComplexityRuleWithThreshold(threshold))
+	}
+	if lineLength, ok := cfg.Thresholds["structure"]; ok {
+		engine.ReplaceRule(rules.NewStructureRuleWithLineLength(lineLength))
+	}
+
+	// The structured "rules.complexity" section is more specific than the
+	// flat "thresholds.complexity" key, so it replaces the rule again if
+	// both are present.
+	if c := cfg.Rules.Complexity; c != (config.ComplexityThresholds{}) {
+		engine.ReplaceRule(rules.NewComplexityRuleFromConfig(rules.ComplexityConfig{
+			CyclomaticMax:    c.CyclomaticMax,
+			FunctionLinesMax: c.FunctionLinesMax,
+			NestingMax:       c.NestingMax,
+		}))
+	}
+}
+
+func removeString(s []string, v string) []string {
+	out := s[:0]
+	for _, item := range s {
+		if item != v {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func init() {
+	rulesListCmd.Flags().StringP("config", "", "", "Configuration file path (.nada.yaml or JSON)")
+	rulesEnableCmd.Flags().StringP("config", "", "", "Configuration file path to edit (default .nada.yaml)")
+	rulesDisableCmd.Flags().StringP("config", "", "", "Configuration file path to edit (default .nada.yaml)")
+
+	rulesCmd.AddCommand(rulesListCmd)
+	rulesCmd.AddCommand(rulesEnableCmd)
+	rulesCmd.AddCommand(rulesDisableCmd)
+	rootCmd.AddCommand(rulesCmd)
+}
@@ -0,0 +1,161 @@
+// Package config loads the optional project configuration file pointed to
+// by AnalysisOptions.ConfigFile (JSON, or YAML when the path ends in
+// ".yaml"/".yml", e.g. the conventional ".nada.yaml"). Both formats decode
+// into the same Config struct via its "json"/"yaml" tags, and both reject an
+// unrecognized key rather than silently ignoring a typo.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chaksack/nada/internal/gate"
+)
+
+// CustomRule declares one Rego-backed policy to load as an additional
+// analysis rule, matching the "custom_rules" config key.
+type CustomRule struct {
+	ID       string `json:"id" yaml:"id"`
+	Path     string `json:"path" yaml:"path"`
+	Severity string `json:"severity" yaml:"severity"`
+	Type     string `json:"type" yaml:"type"`
+}
+
+// Config is the top-level shape of a project's config file.
+type Config struct {
+	CustomRules []CustomRule `json:"custom_rules" yaml:"custom_rules"`
+
+	// RulePacksDir is a directory of *.yaml/*.yml pattern rule packs
+	// (see rules.LoadRulePackDir) to load in addition to CustomRules,
+	// resolved relative to the config file's own directory. Empty means
+	// only the project's conventional "rules/" directory, if present, is
+	// auto-discovered.
+	RulePacksDir string `json:"rule_packs_dir" yaml:"rule_packs_dir"`
+
+	// EnabledRules and DisabledRules toggle rules by ID on top of the
+	// engine's defaults; DisabledRules wins if a rule appears in both.
+	EnabledRules  []string `json:"enabled_rules" yaml:"enabled_rules"`
+	DisabledRules []string `json:"disabled_rules" yaml:"disabled_rules"`
+
+	// Thresholds tunes built-in rule limits by rule ID, e.g.
+	// {"complexity": 15, "structure": 100} for the complexity and
+	// line-length checks.
+	Thresholds map[string]int `json:"thresholds" yaml:"thresholds"`
+
+	// Linters names external linters (see rules.DefaultExternalLinters) to
+	// run by default for this project; --linters overrides this list for a
+	// single run rather than merging with it, the same "flag replaces file"
+	// precedence output format and exclude patterns already follow.
+	Linters []string `json:"linters" yaml:"linters"`
+
+	// ExcludeFiles is a default set of exclude globs (see
+	// AnalysisOptions.ExcludeFiles); --exclude overrides rather than merges,
+	// the same precedence Linters follows.
+	ExcludeFiles []string `json:"exclude_files" yaml:"exclude_files"`
+
+	// Rules holds per-rule threshold overrides, nested by rule ID - e.g.
+	// "rules.complexity.cyclomatic_max" (see RulesConfig). This sits
+	// alongside the older flat Thresholds map rather than replacing it, so
+	// existing ".nada.yaml" files with "thresholds: {complexity: 15}" still
+	// work; a rule present in both wins from Rules, since it's the more
+	// specific of the two (see cli.applyRuleConfig).
+	Rules RulesConfig `json:"rules" yaml:"rules"`
+
+	// QualityGate declares the grade/severity/coverage floor this project
+	// must meet, an alternative to passing --gate-config/--gate-fail-on on
+	// every invocation (see gate.Gate, which this is translated into).
+	QualityGate QualityGateConfig `json:"quality_gate" yaml:"quality_gate"`
+}
+
+// RulesConfig nests per-rule threshold overrides under their rule ID, the
+// structured counterpart to the flat Thresholds map.
+type RulesConfig struct {
+	Complexity ComplexityThresholds `json:"complexity" yaml:"complexity"`
+}
+
+// ComplexityThresholds tunes rules.ComplexityRule's three independent
+// checks; a zero field means "use the rule's built-in default" (see
+// rules.ComplexityConfig, which this is translated into by
+// cli.applyRuleConfig).
+type ComplexityThresholds struct {
+	CyclomaticMax    int `json:"cyclomatic_max" yaml:"cyclomatic_max"`
+	FunctionLinesMax int `json:"function_lines_max" yaml:"function_lines_max"`
+	NestingMax       int `json:"nesting_max" yaml:"nesting_max"`
+}
+
+// QualityGateConfig is the structured alternative to a --gate-config YAML
+// file: GradeFloor and MaxHighSeverity/MinCoverage are translated into
+// gate.Condition values by ToGate. A pointer MaxHighSeverity/MinCoverage
+// distinguishes "not set" from "set to zero", since zero is itself a
+// meaningful floor (e.g. "no high-severity issues at all").
+type QualityGateConfig struct {
+	GradeFloor      string   `json:"grade_floor" yaml:"grade_floor"`
+	MaxHighSeverity *int     `json:"max_high_severity" yaml:"max_high_severity"`
+	MinCoverage     *float64 `json:"min_coverage" yaml:"min_coverage"`
+}
+
+// IsZero reports whether none of QualityGateConfig's fields were set, so
+// callers can tell "no quality_gate section in the file" from "a gate with
+// conditions".
+func (q QualityGateConfig) IsZero() bool {
+	return q.GradeFloor == "" && q.MaxHighSeverity == nil && q.MinCoverage == nil
+}
+
+// ToGate translates q into a *gate.Gate: GradeFloor becomes a "grade >=" floor,
+// MaxHighSeverity a "high_severity <=" ceiling, and MinCoverage a
+// "coverage >=" floor, each included only if set.
+func (q QualityGateConfig) ToGate() (*gate.Gate, error) {
+	g := &gate.Gate{}
+
+	if q.GradeFloor != "" {
+		rank, ok := gate.GradeRank(q.GradeFloor)
+		if !ok {
+			return nil, fmt.Errorf("quality_gate.grade_floor: unrecognized grade %q", q.GradeFloor)
+		}
+		g.Conditions = append(g.Conditions, gate.Condition{Metric: "grade", Op: ">=", Value: rank})
+	}
+
+	if q.MaxHighSeverity != nil {
+		g.Conditions = append(g.Conditions, gate.Condition{Metric: "high_severity", Op: "<=", Value: float64(*q.MaxHighSeverity)})
+	}
+
+	if q.MinCoverage != nil {
+		g.Conditions = append(g.Conditions, gate.Condition{Metric: "coverage", Op: ">=", Value: *q.MinCoverage})
+	}
+
+	return g, nil
+}
+
+// Load reads and parses the config file at path, dispatching on its
+// extension: ".yaml"/".yml" is parsed as YAML, anything else as JSON.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		return parseYAML(data)
+	}
+
+	var cfg Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("unknown or malformed config field: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// SaveYAML writes cfg to path in the YAML shape parseYAML reads back, for
+// `nada rules enable`/`disable` to persist a toggle. Like a JSON round-trip,
+// this doesn't preserve comments or formatting from an existing file.
+func SaveYAML(cfg *Config, path string) error {
+	return os.WriteFile(path, marshalYAML(cfg), 0644)
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nada.json")
+	data := `{
+		"custom_rules": [
+			{"id": "no_fmt_println", "path": "policies/no_fmt_println.rego", "severity": "medium", "type": "code_smell"}
+		]
+	}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(cfg.CustomRules) != 1 {
+		t.Fatalf("Load().CustomRules = %d entries, want 1", len(cfg.CustomRules))
+	}
+	rule := cfg.CustomRules[0]
+	if rule.ID != "no_fmt_println" || rule.Path != "policies/no_fmt_println.rego" || rule.Severity != "medium" || rule.Type != "code_smell" {
+		t.Errorf("Load().CustomRules[0] = %+v, unexpected", rule)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() error = nil, want an error for a missing file")
+	}
+}
+
+func TestLoadUnknownField(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "nada.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"thresholds": {"complexity": 15}, "typo_field": true}`), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := Load(jsonPath); err == nil {
+		t.Error("Load() error = nil, want an error for an unknown JSON field")
+	}
+
+	yamlPath := filepath.Join(dir, "nada.yaml")
+	if err := os.WriteFile(yamlPath, []byte("typo_field: true\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := Load(yamlPath); err == nil {
+		t.Error("Load() error = nil, want an error for an unknown YAML field")
+	}
+}
+
+func TestLoadRulesComplexityThresholds(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nada.yaml")
+	data := "rules:\n  complexity:\n    cyclomatic_max: 15\n    function_lines_max: 80\n    nesting_max: 6\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := ComplexityThresholds{CyclomaticMax: 15, FunctionLinesMax: 80, NestingMax: 6}
+	if cfg.Rules.Complexity != want {
+		t.Errorf("Load().Rules.Complexity = %+v, want %+v", cfg.Rules.Complexity, want)
+	}
+}
+
+func TestLoadQualityGate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nada.yaml")
+	data := "quality_gate:\n  grade_floor: B\n  max_high_severity: 0\n  min_coverage: 75.5\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.QualityGate.GradeFloor != "B" {
+		t.Errorf("Load().QualityGate.GradeFloor = %q, want \"B\"", cfg.QualityGate.GradeFloor)
+	}
+	if cfg.QualityGate.MaxHighSeverity == nil || *cfg.QualityGate.MaxHighSeverity != 0 {
+		t.Errorf("Load().QualityGate.MaxHighSeverity = %v, want *0", cfg.QualityGate.MaxHighSeverity)
+	}
+	if cfg.QualityGate.MinCoverage == nil || *cfg.QualityGate.MinCoverage != 75.5 {
+		t.Errorf("Load().QualityGate.MinCoverage = %v, want *75.5", cfg.QualityGate.MinCoverage)
+	}
+
+	g, err := cfg.QualityGate.ToGate()
+	if err != nil {
+		t.Fatalf("ToGate() error = %v", err)
+	}
+	if len(g.Conditions) != 3 {
+		t.Errorf("ToGate().Conditions = %d entries, want 3", len(g.Conditions))
+	}
+}
@@ -0,0 +1,350 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseYAML decodes the fixed shape Config uses (a handful of top-level
+// scalars, a "custom_rules" list of maps, "enabled_rules"/"disabled_rules"/
+// "exclude_files"/"linters" lists of scalars, a flat "thresholds" map, the
+// one-level-deeper "rules.complexity" map, and the "quality_gate" map). It's
+// a hand-rolled subset of YAML rather than a vendored decoder, for the same
+// reason rules.parseRulePackYAML and rego.go's CLIEvaluator avoid pulling in
+// a third-party module: this tree has no go.mod to add one to.
+func parseYAML(data []byte) (*Config, error) {
+	cfg := &Config{}
+
+	var currentRule *CustomRule
+	section := ""
+	// rulesSubsection and rulesSubsectionIndent track "rules: complexity:",
+	// the one nesting level deeper than every other section needs: a line
+	// indented past rulesSubsectionIndent is one of that subsection's own
+	// keys, not a sibling of "complexity" within "rules".
+	rulesSubsection := ""
+	rulesSubsectionIndent := -1
+
+	flushRule := func() {
+		if currentRule != nil {
+			cfg.CustomRules = append(cfg.CustomRules, *currentRule)
+			currentRule = nil
+		}
+	}
+
+	for lineNum, raw := range strings.Split(string(data), "\n") {
+		line := stripComment(raw)
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Top-level key (no leading indentation) starts a new section.
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			flushRule()
+			rulesSubsection, rulesSubsectionIndent = "", -1
+			key, value, ok := splitKeyValue(line)
+			if !ok {
+				return nil, fmt.Errorf("line %d: expected a YAML key", lineNum+1)
+			}
+			section = key
+			if value != "" {
+				if err := cfg.setScalar(key, value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNum+1, err)
+				}
+				section = ""
+			}
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := len(line) - len(trimmed)
+
+		switch section {
+		case "custom_rules":
+			if strings.HasPrefix(trimmed, "- ") {
+				flushRule()
+				currentRule = &CustomRule{}
+				trimmed = strings.TrimPrefix(trimmed, "- ")
+			}
+			if currentRule == nil {
+				return nil, fmt.Errorf("line %d: custom_rules entry missing leading \"- \"", lineNum+1)
+			}
+			key, value, ok := splitKeyValue(trimmed)
+			if !ok {
+				continue
+			}
+			if err := currentRule.set(key, value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum+1, err)
+			}
+		case "enabled_rules", "disabled_rules", "exclude_files", "linters":
+			if !strings.HasPrefix(trimmed, "- ") {
+				continue
+			}
+			value := unquote(strings.TrimSpace(strings.TrimPrefix(trimmed, "- ")))
+			switch section {
+			case "enabled_rules":
+				cfg.EnabledRules = append(cfg.EnabledRules, value)
+			case "disabled_rules":
+				cfg.DisabledRules = append(cfg.DisabledRules, value)
+			case "exclude_files":
+				cfg.ExcludeFiles = append(cfg.ExcludeFiles, value)
+			case "linters":
+				cfg.Linters = append(cfg.Linters, value)
+			}
+		case "thresholds":
+			key, value, ok := splitKeyValue(trimmed)
+			if !ok {
+				continue
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: threshold %q must be an integer: %w", lineNum+1, key, err)
+			}
+			if cfg.Thresholds == nil {
+				cfg.Thresholds = make(map[string]int)
+			}
+			cfg.Thresholds[key] = n
+		case "rules":
+			if rulesSubsection != "" && indent > rulesSubsectionIndent {
+				key, value, ok := splitKeyValue(trimmed)
+				if !ok {
+					continue
+				}
+				if err := cfg.Rules.Complexity.set(key, value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNum+1, err)
+				}
+				continue
+			}
+			key, value, ok := splitKeyValue(trimmed)
+			if !ok {
+				return nil, fmt.Errorf("line %d: expected a YAML key", lineNum+1)
+			}
+			if key != "complexity" || value != "" {
+				return nil, fmt.Errorf("line %d: unknown rules field %q", lineNum+1, key)
+			}
+			rulesSubsection, rulesSubsectionIndent = key, indent
+		case "quality_gate":
+			key, value, ok := splitKeyValue(trimmed)
+			if !ok {
+				continue
+			}
+			if err := cfg.QualityGate.set(key, value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum+1, err)
+			}
+		}
+	}
+	flushRule()
+
+	return cfg, nil
+}
+
+// set assigns one "rules.complexity" key, erroring on anything but
+// ComplexityThresholds' three fields.
+func (c *ComplexityThresholds) set(key, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("rules.complexity.%s must be an integer: %w", key, err)
+	}
+	switch key {
+	case "cyclomatic_max":
+		c.CyclomaticMax = n
+	case "function_lines_max":
+		c.FunctionLinesMax = n
+	case "nesting_max":
+		c.NestingMax = n
+	default:
+		return fmt.Errorf("unknown rules.complexity field %q", key)
+	}
+	return nil
+}
+
+// set assigns one "quality_gate" key, erroring on anything but
+// QualityGateConfig's three fields.
+func (q *QualityGateConfig) set(key, value string) error {
+	switch key {
+	case "grade_floor":
+		q.GradeFloor = unquote(value)
+	case "max_high_severity":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("quality_gate.max_high_severity must be an integer: %w", err)
+		}
+		q.MaxHighSeverity = &n
+	case "min_coverage":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("quality_gate.min_coverage must be a number: %w", err)
+		}
+		q.MinCoverage = &f
+	default:
+		return fmt.Errorf("unknown quality_gate field %q", key)
+	}
+	return nil
+}
+
+// setScalar assigns a top-level "key: value" pair to the one scalar field
+// Config has; list-valued keys are handled by their section's indented
+// lines instead.
+func (c *Config) setScalar(key, value string) error {
+	switch key {
+	case "rule_packs_dir":
+		c.RulePacksDir = unquote(value)
+	case "custom_rules", "enabled_rules", "disabled_rules", "thresholds",
+		"linters", "exclude_files", "rules", "quality_gate":
+		return fmt.Errorf("%q must be a list or map, not an inline value", key)
+	default:
+		return fmt.Errorf("unknown config field %q", key)
+	}
+	return nil
+}
+
+func (r *CustomRule) set(key, value string) error {
+	value = unquote(value)
+	switch key {
+	case "id":
+		r.ID = value
+	case "path":
+		r.Path = value
+	case "severity":
+		r.Severity = value
+	case "type":
+		r.Type = value
+	default:
+		return fmt.Errorf("unknown custom_rules field %q", key)
+	}
+	return nil
+}
+
+// stripComment removes a trailing "# ..." comment, ignoring '#' inside a
+// quoted string.
+func stripComment(line string) string {
+	inSingle, inDouble := false, false
+	for i, c := range line {
+		switch c {
+		case '\'':
+			if !inDouble {
+				inSingle = !inSingle
+			}
+		case '"':
+			if !inSingle {
+				inDouble = !inDouble
+			}
+		case '#':
+			if !inSingle && !inDouble && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// splitKeyValue splits a "key: value" line (value may be empty, for a key
+// whose content is on following indented lines).
+func splitKeyValue(line string) (key, value string, ok bool) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return "", "", false
+	}
+	key = strings.TrimSpace(line[:idx])
+	value = strings.TrimSpace(line[idx+1:])
+	return key, value, key != ""
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// marshalYAML renders cfg in the shape parseYAML reads back. It only needs
+// to cover the fields Config actually has, in the order parseYAML expects
+// sections to appear.
+func marshalYAML(cfg *Config) []byte {
+	var b strings.Builder
+
+	if cfg.RulePacksDir != "" {
+		fmt.Fprintf(&b, "rule_packs_dir: %s\n", cfg.RulePacksDir)
+	}
+
+	if len(cfg.CustomRules) > 0 {
+		b.WriteString("custom_rules:\n")
+		for _, cr := range cfg.CustomRules {
+			fmt.Fprintf(&b, "  - id: %s\n", cr.ID)
+			fmt.Fprintf(&b, "    path: %s\n", cr.Path)
+			fmt.Fprintf(&b, "    severity: %s\n", cr.Severity)
+			fmt.Fprintf(&b, "    type: %s\n", cr.Type)
+		}
+	}
+
+	if len(cfg.EnabledRules) > 0 {
+		b.WriteString("enabled_rules:\n")
+		for _, id := range cfg.EnabledRules {
+			fmt.Fprintf(&b, "  - %s\n", id)
+		}
+	}
+
+	if len(cfg.DisabledRules) > 0 {
+		b.WriteString("disabled_rules:\n")
+		for _, id := range cfg.DisabledRules {
+			fmt.Fprintf(&b, "  - %s\n", id)
+		}
+	}
+
+	if len(cfg.Thresholds) > 0 {
+		b.WriteString("thresholds:\n")
+		keys := make([]string, 0, len(cfg.Thresholds))
+		for k := range cfg.Thresholds {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "  %s: %d\n", k, cfg.Thresholds[k])
+		}
+	}
+
+	if len(cfg.Linters) > 0 {
+		b.WriteString("linters:\n")
+		for _, name := range cfg.Linters {
+			fmt.Fprintf(&b, "  - %s\n", name)
+		}
+	}
+
+	if len(cfg.ExcludeFiles) > 0 {
+		b.WriteString("exclude_files:\n")
+		for _, pattern := range cfg.ExcludeFiles {
+			fmt.Fprintf(&b, "  - %s\n", pattern)
+		}
+	}
+
+	if c := cfg.Rules.Complexity; c != (ComplexityThresholds{}) {
+		b.WriteString("rules:\n  complexity:\n")
+		if c.CyclomaticMax != 0 {
+			fmt.Fprintf(&b, "    cyclomatic_max: %d\n", c.CyclomaticMax)
+		}
+		if c.FunctionLinesMax != 0 {
+			fmt.Fprintf(&b, "    function_lines_max: %d\n", c.FunctionLinesMax)
+		}
+		if c.NestingMax != 0 {
+			fmt.Fprintf(&b, "    nesting_max: %d\n", c.NestingMax)
+		}
+	}
+
+	if !cfg.QualityGate.IsZero() {
+		b.WriteString("quality_gate:\n")
+		if cfg.QualityGate.GradeFloor != "" {
+			fmt.Fprintf(&b, "  grade_floor: %s\n", cfg.QualityGate.GradeFloor)
+		}
+		if cfg.QualityGate.MaxHighSeverity != nil {
+			fmt.Fprintf(&b, "  max_high_severity: %d\n", *cfg.QualityGate.MaxHighSeverity)
+		}
+		if cfg.QualityGate.MinCoverage != nil {
+			fmt.Fprintf(&b, "  min_coverage: %g\n", *cfg.QualityGate.MinCoverage)
+		}
+	}
+
+	return []byte(b.String())
+}
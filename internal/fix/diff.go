@@ -0,0 +1,190 @@
+package fix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each changed
+// region, matching `diff -u`'s default.
+const diffContext = 3
+
+// unifiedDiff renders a `diff -u`-style patch from oldContent to newContent,
+// used both by --fix-dry-run's preview and Result.Diff for an applied fix.
+func unifiedDiff(path, oldContent, newContent string) string {
+	if oldContent == newContent {
+		return ""
+	}
+
+	oldLines := strings.Split(oldContent, "\n")
+	newLines := strings.Split(newContent, "\n")
+	ops := diffLines(oldLines, newLines)
+	hunks := groupHunks(ops, diffContext)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n", path)
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+	for _, h := range hunks {
+		writeHunk(&b, oldLines, newLines, ops[h.start:h.end])
+	}
+	return b.String()
+}
+
+// opKind distinguishes the three edits a line-level diff can report.
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// op is one aligned step through oldLines/newLines: Equal consumes one line
+// from each, Delete consumes one from oldLines only, Insert one from
+// newLines only.
+type op struct {
+	kind opKind
+	old  int // index into oldLines, valid for opEqual/opDelete
+	new  int // index into newLines, valid for opEqual/opInsert
+}
+
+// diffLines aligns oldLines and newLines via their longest common
+// subsequence, computed by the textbook O(n*m) dynamic-programming table.
+// A fix's edits touch one file at a time and are typically a handful of
+// lines, so this never runs over whole-repo input the way HashContent or
+// computeFingerprint do.
+func diffLines(oldLines, newLines []string) []op {
+	n, m := len(oldLines), len(newLines)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case oldLines[i] == newLines[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case oldLines[i] == newLines[j]:
+			ops = append(ops, op{kind: opEqual, old: i, new: j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{kind: opDelete, old: i})
+			i++
+		default:
+			ops = append(ops, op{kind: opInsert, new: j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, op{kind: opDelete, old: i})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, op{kind: opInsert, new: j})
+	}
+	return ops
+}
+
+// hunk is a contiguous slice of ops worth showing together, padded with up
+// to `context` unchanged lines of lead-in/lead-out.
+type hunk struct {
+	start, end int // half-open range into ops
+}
+
+// groupHunks merges changed regions of ops that are within 2*context lines
+// of each other into a single hunk, the same collapsing `diff -u` does so a
+// file with scattered edits doesn't print one hunk per line.
+func groupHunks(ops []op, context int) []hunk {
+	var changed []int
+	for idx, o := range ops {
+		if o.kind != opEqual {
+			changed = append(changed, idx)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	var hunks []hunk
+	start := max(0, changed[0]-context)
+	end := min(len(ops), changed[0]+1+context)
+	for _, idx := range changed[1:] {
+		lo := max(0, idx-context)
+		hi := min(len(ops), idx+1+context)
+		if lo <= end {
+			end = hi
+			continue
+		}
+		hunks = append(hunks, hunk{start: start, end: end})
+		start, end = lo, hi
+	}
+	hunks = append(hunks, hunk{start: start, end: end})
+	return hunks
+}
+
+// writeHunk renders one hunk in `diff -u` form, with a "@@ -l,c +l,c @@"
+// header giving each side's 1-indexed starting line and line count.
+func writeHunk(b *strings.Builder, oldLines, newLines []string, ops []op) {
+	oldStart, newStart := -1, -1
+	var oldCount, newCount int
+	var body strings.Builder
+
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			if oldStart == -1 {
+				oldStart, newStart = o.old, o.new
+			}
+			oldCount++
+			newCount++
+			fmt.Fprintf(&body, " %s\n", oldLines[o.old])
+		case opDelete:
+			if oldStart == -1 {
+				oldStart = o.old
+			}
+			oldCount++
+			fmt.Fprintf(&body, "-%s\n", oldLines[o.old])
+		case opInsert:
+			if newStart == -1 {
+				newStart = o.new
+			}
+			newCount++
+			fmt.Fprintf(&body, "+%s\n", newLines[o.new])
+		}
+	}
+	if oldStart == -1 {
+		oldStart = 0
+	}
+	if newStart == -1 {
+		newStart = 0
+	}
+
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldStart+1, oldCount, newStart+1, newCount)
+	b.WriteString(body.String())
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
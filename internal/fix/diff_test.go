@@ -0,0 +1,56 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiffNoChangeReturnsEmpty(t *testing.T) {
+	if got := unifiedDiff("main.go", "same\n", "same\n"); got != "" {
+		t.Errorf("unifiedDiff() = %q, want empty string for identical content", got)
+	}
+}
+
+func TestUnifiedDiffReportsHeaderAndHunk(t *testing.T) {
+	old := "package main\n\nfunc foo() {}\n"
+	next := "package main\n\n// Foo ...\nfunc foo() {}\n"
+
+	got := unifiedDiff("main.go", old, next)
+
+	for _, want := range []string{"--- a/main.go\n", "+++ b/main.go\n", "@@ ", "+// Foo ...\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("unifiedDiff() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestDiffLinesAlignsOnLongestCommonSubsequence(t *testing.T) {
+	ops := diffLines([]string{"a", "b", "c"}, []string{"a", "x", "c"})
+
+	var kinds []opKind
+	for _, o := range ops {
+		kinds = append(kinds, o.kind)
+	}
+
+	want := []opKind{opEqual, opDelete, opInsert, opEqual}
+	if len(kinds) != len(want) {
+		t.Fatalf("diffLines() produced %d ops, want %d: %v", len(kinds), len(want), kinds)
+	}
+	for i, k := range kinds {
+		if k != want[i] {
+			t.Errorf("ops[%d].kind = %v, want %v", i, k, want[i])
+		}
+	}
+}
+
+func TestGroupHunksMergesNearbyChanges(t *testing.T) {
+	ops := []op{
+		{kind: opEqual}, {kind: opDelete}, {kind: opEqual},
+		{kind: opEqual}, {kind: opInsert}, {kind: opEqual},
+	}
+
+	hunks := groupHunks(ops, 1)
+	if len(hunks) != 1 {
+		t.Fatalf("groupHunks() = %d hunks, want 1 (changes within 2*context should merge): %+v", len(hunks), hunks)
+	}
+}
@@ -0,0 +1,212 @@
+// Package fix applies the types.SuggestedFix edits a Rule attaches to an
+// Issue: `nada analyze --fix` calls Fixer.Apply on the resulting report's
+// issues to rewrite source files in place, and --fix-dry-run calls it with
+// DryRun set to preview a unified diff without touching anything.
+package fix
+
+import (
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// Fixer applies the SuggestedFix edits attached to a set of issues.
+type Fixer struct {
+	// Unsafe allows fixes marked types.SuggestedFix.Unsafe to be applied -
+	// the --fix=unsafe flag's effect. False applies only mechanical,
+	// non-semantic fixes.
+	Unsafe bool
+
+	// DryRun computes and returns each file's unified diff without writing
+	// it - the --fix-dry-run flag's effect.
+	DryRun bool
+}
+
+// New builds a Fixer. unsafe enables fixes marked types.SuggestedFix.Unsafe.
+func New(unsafe bool) *Fixer {
+	return &Fixer{Unsafe: unsafe}
+}
+
+// Result is what Apply reports for one file.
+type Result struct {
+	File string
+
+	// Applied is the number of edits written (or, in dry-run, that would be
+	// written).
+	Applied int
+
+	// Skipped lists edits dropped because they overlapped an
+	// already-accepted edit in the same file, earliest-offset-wins.
+	Skipped []string
+
+	// Diff is the unified diff of the change, populated whenever at least
+	// one edit applied, whether or not DryRun is set.
+	Diff string
+
+	// RolledBack is set when the edited file failed to parse as Go source
+	// afterward; in that case nothing was written and Diff/Applied describe
+	// the change that was discarded.
+	RolledBack bool
+
+	// Err holds a read, parse-verification, or write failure that stopped
+	// this file's fix short, distinct from RolledBack (a successful parse
+	// that came back invalid).
+	Err error
+}
+
+// Apply groups every Fixes edit across issues by file, resolves overlaps,
+// and applies each file's non-overlapping edits in one pass: in reverse
+// byte-offset order, so applying an edit never invalidates the offsets of
+// edits still to come. The result is re-parsed with go/parser to verify it
+// is still syntactically valid Go; a file that fails that check is rolled
+// back (left untouched) rather than written. A file that parses is then
+// run through go/format, the same gofmt formatting `go build` itself
+// assumes source already has, since an edit's NewText (a renamed
+// identifier, an inserted doc-comment stub) carries no indentation of its
+// own to match the surrounding block. Returns one Result per file that had
+// at least one eligible edit, in sorted file order, regardless of DryRun.
+func (f *Fixer) Apply(issues []types.Issue) ([]Result, error) {
+	editsByFile := make(map[string][]types.TextEdit)
+	for _, issue := range issues {
+		for _, sf := range issue.Fixes {
+			if sf.Unsafe && !f.Unsafe {
+				continue
+			}
+			for _, edit := range sf.Edits {
+				editsByFile[edit.File] = append(editsByFile[edit.File], edit)
+			}
+		}
+	}
+
+	files := make([]string, 0, len(editsByFile))
+	for file := range editsByFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	results := make([]Result, 0, len(files))
+	for _, file := range files {
+		result, err := f.applyFile(file, editsByFile[file])
+		if err != nil {
+			return results, err
+		}
+		if result.Applied > 0 {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// applyFile applies edits to one file, per Apply's contract.
+func (f *Fixer) applyFile(file string, edits []types.TextEdit) (Result, error) {
+	result := Result{File: file}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		result.Err = fmt.Errorf("reading %s: %w", file, err)
+		return result, nil
+	}
+
+	accepted, skipped := nonOverlapping(edits)
+	result.Skipped = skipped
+	if len(accepted) == 0 {
+		return result, nil
+	}
+
+	newContent := applyEdits(string(content), accepted)
+	result.Applied = len(accepted)
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, file, newContent, parser.ParseComments); err != nil {
+		result.RolledBack = true
+		result.Diff = unifiedDiff(file, string(content), newContent)
+		return result, nil
+	}
+
+	if formatted, err := format.Source([]byte(newContent)); err == nil {
+		newContent = string(formatted)
+	}
+	result.Diff = unifiedDiff(file, string(content), newContent)
+
+	if f.DryRun {
+		return result, nil
+	}
+
+	if err := writeAtomic(file, []byte(newContent)); err != nil {
+		result.Err = fmt.Errorf("writing %s: %w", file, err)
+	}
+	return result, nil
+}
+
+// nonOverlapping sorts edits by Start ascending and keeps the first of any
+// pair whose byte ranges overlap, dropping the rest; edits from different
+// rules proposing incompatible changes to the same region both show up
+// here, so ties are broken by input order, not rule identity. It returns
+// the accepted edits still sorted by Start, plus a human-readable
+// description of each one skipped.
+func nonOverlapping(edits []types.TextEdit) ([]types.TextEdit, []string) {
+	sorted := append([]types.TextEdit(nil), edits...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var accepted []types.TextEdit
+	var skipped []string
+	prevEnd := -1
+	for _, edit := range sorted {
+		if edit.Start < prevEnd {
+			skipped = append(skipped, fmt.Sprintf("edit at [%d,%d) overlaps a previously accepted edit", edit.Start, edit.End))
+			continue
+		}
+		accepted = append(accepted, edit)
+		prevEnd = edit.End
+	}
+	return accepted, skipped
+}
+
+// applyEdits rewrites content by applying edits (assumed sorted ascending
+// by Start and non-overlapping) in reverse order, so replacing a later
+// range never shifts the byte offsets an earlier edit still relies on.
+func applyEdits(content string, edits []types.TextEdit) string {
+	for i := len(edits) - 1; i >= 0; i-- {
+		edit := edits[i]
+		content = content[:edit.Start] + edit.NewText + content[edit.End:]
+	}
+	return content
+}
+
+// writeAtomic writes data to path via a temp file in the same directory
+// followed by a rename, so a crash mid-write can never leave path
+// truncated or half-written.
+func writeAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".nada-fix-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	mode := os.FileMode(0644)
+	if err == nil {
+		mode = info.Mode()
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
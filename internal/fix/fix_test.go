@@ -0,0 +1,188 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile(%s): %v", name, err)
+	}
+	return path
+}
+
+func TestApplyWritesNonOverlappingEdits(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "main.go", "package main\n\nfunc foo() {}\n")
+
+	issues := []types.Issue{{
+		Fixes: []types.SuggestedFix{{
+			Message: "doc stub",
+			Edits:   []types.TextEdit{{File: path, Start: 14, End: 14, NewText: "// Foo ...\n"}},
+		}},
+	}}
+
+	f := New(false)
+	results, err := f.Apply(issues)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Apply() returned %d results, want 1", len(results))
+	}
+	if results[0].Applied != 1 {
+		t.Errorf("Applied = %d, want 1", results[0].Applied)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package main\n\n// Foo ...\nfunc foo() {}\n"
+	if string(got) != want {
+		t.Errorf("file content = %q, want %q", got, want)
+	}
+}
+
+func TestApplySkipsUnsafeFixesByDefault(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "main.go", "package main\n\nfunc foo() {}\n")
+
+	issues := []types.Issue{{
+		Fixes: []types.SuggestedFix{{
+			Message: "rename",
+			Edits:   []types.TextEdit{{File: path, Start: 19, End: 22, NewText: "Bar"}},
+			Unsafe:  true,
+		}},
+	}}
+
+	f := New(false)
+	results, err := f.Apply(issues)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("Apply() applied an unsafe fix without --fix=unsafe: %+v", results)
+	}
+
+	f = New(true)
+	results, err = f.Apply(issues)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Applied != 1 {
+		t.Fatalf("Apply() with Unsafe=true did not apply the fix: %+v", results)
+	}
+}
+
+func TestApplySkipsOverlappingEdits(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "main.go", "package main\n\nfunc foo() {}\n")
+
+	issues := []types.Issue{{
+		Fixes: []types.SuggestedFix{
+			{Edits: []types.TextEdit{{File: path, Start: 19, End: 22, NewText: "Bar"}}},
+			{Edits: []types.TextEdit{{File: path, Start: 21, End: 24, NewText: "Baz"}}},
+		},
+	}}
+
+	results, err := New(false).Apply(issues)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Apply() returned %d results, want 1", len(results))
+	}
+	if results[0].Applied != 1 {
+		t.Errorf("Applied = %d, want 1", results[0].Applied)
+	}
+	if len(results[0].Skipped) != 1 {
+		t.Errorf("Skipped = %v, want 1 entry", results[0].Skipped)
+	}
+}
+
+func TestApplyRollsBackOnInvalidSyntax(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "main.go", "package main\n\nfunc foo() {}\n")
+	original, _ := os.ReadFile(path)
+
+	issues := []types.Issue{{
+		Fixes: []types.SuggestedFix{{
+			Edits: []types.TextEdit{{File: path, Start: 0, End: 0, NewText: "???"}},
+		}},
+	}}
+
+	results, err := New(false).Apply(issues)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(results) != 1 || !results[0].RolledBack {
+		t.Fatalf("Apply() did not report a rollback: %+v", results)
+	}
+
+	got, _ := os.ReadFile(path)
+	if string(got) != string(original) {
+		t.Errorf("file was modified despite rollback: %q", got)
+	}
+}
+
+func TestApplyFormatsSourceAfterEdit(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "main.go", "package main\n\nfunc foo() {}\n")
+
+	issues := []types.Issue{{
+		Fixes: []types.SuggestedFix{{
+			Edits: []types.TextEdit{{File: path, Start: 14, End: 14, NewText: "func   bar( )   {}\n"}},
+		}},
+	}}
+
+	results, err := New(false).Apply(issues)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Applied != 1 {
+		t.Fatalf("Apply() returned %+v, want one applied edit", results)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package main\n\nfunc bar() {}\nfunc foo() {}\n"
+	if string(got) != want {
+		t.Errorf("file content = %q, want gofmt-normalized %q", got, want)
+	}
+}
+
+func TestApplyDryRunLeavesFileUntouched(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "main.go", "package main\n\nfunc foo() {}\n")
+	original, _ := os.ReadFile(path)
+
+	issues := []types.Issue{{
+		Fixes: []types.SuggestedFix{{
+			Edits: []types.TextEdit{{File: path, Start: 14, End: 14, NewText: "// Foo ...\n"}},
+		}},
+	}}
+
+	f := New(false)
+	f.DryRun = true
+	results, err := f.Apply(issues)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Diff == "" {
+		t.Fatalf("Apply() dry-run did not produce a diff: %+v", results)
+	}
+
+	got, _ := os.ReadFile(path)
+	if string(got) != string(original) {
+		t.Errorf("dry-run wrote to %s: %q", path, got)
+	}
+}
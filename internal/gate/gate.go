@@ -0,0 +1,221 @@
+// Package gate evaluates user-declared quality-gate conditions against an
+// analysis Report, so CI can fail a build on a configurable threshold (e.g.
+// "security_score >= 80") instead of the fixed checks reporter.printQualityGates
+// hardcodes. A "new_"-prefixed metric (e.g. new_vulnerabilities) is counted
+// over the diff against a baseline report instead of the report as a whole,
+// the same SonarQube-style pattern internal/ignore's checksum baseline and
+// analyze's --baseline flag already use to let legacy debt through while
+// blocking regressions.
+package gate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// Condition is one check in a Gate: metric compared against value using op
+// (one of >=, <=, >, <, ==, !=).
+type Condition struct {
+	Metric string
+	Op     string
+	Value  float64
+}
+
+// Gate is the set of conditions a report must satisfy to pass.
+type Gate struct {
+	Conditions []Condition
+}
+
+// Load reads a Gate from a YAML file at path.
+func Load(path string) (*Gate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gate file: %w", err)
+	}
+
+	gate, err := parseYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gate file: %w", err)
+	}
+	return gate, nil
+}
+
+// failOnMetric maps a --gate-fail-on shorthand name (a types.Severity or
+// types.Issue type) to the absolute metric that must be zero for the gate
+// to pass. A "new-"-prefixed variant (e.g. "new-high") maps to the
+// corresponding "new_"-prefixed diff metric instead, so a legacy project
+// with a --baseline loaded can fail only on regressions rather than its
+// accumulated debt - the standard "quality gate on new code" pattern.
+var failOnMetric = map[string]string{
+	types.SeverityHigh:      "high_severity",
+	types.SeverityMedium:    "medium_severity",
+	types.SeverityLow:       "low_severity",
+	types.TypeVulnerability: "vulnerabilities",
+	types.TypeBug:           "bugs",
+	"new-high":              "new_high_severity",
+}
+
+// NewFailOnGate builds a Gate from --gate-fail-on's comma-separated
+// shorthand (e.g. "high,vulnerability,new-high"): one condition per name
+// requiring that metric's count to be zero. Unknown names are rejected up
+// front so a typo doesn't silently pass every run.
+func NewFailOnGate(names []string) (*Gate, error) {
+	gate := &Gate{}
+	for _, name := range names {
+		metric, ok := failOnMetric[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown --gate-fail-on value %q (want one of high, medium, low, vulnerability, bug, new-high)", name)
+		}
+		gate.Conditions = append(gate.Conditions, Condition{Metric: metric, Op: "==", Value: 0})
+	}
+	return gate, nil
+}
+
+// Evaluate checks every condition in gate against report, returning one
+// types.QualityGate result per condition in order. "new_"-prefixed metrics
+// are counted over report.Diff(baseline); baseline may be nil, in which case
+// every issue in report counts as new, since there's nothing to ratchet
+// against yet.
+func Evaluate(gate *Gate, report *types.Report, baseline *types.Report) []types.QualityGate {
+	diff := types.ReportDiff{New: report.Issues}
+	if baseline != nil {
+		diff = report.Diff(baseline)
+	}
+
+	results := make([]types.QualityGate, 0, len(gate.Conditions))
+	for _, cond := range gate.Conditions {
+		results = append(results, evaluateCondition(cond, report, diff))
+	}
+	return results
+}
+
+// evaluateCondition resolves cond's metric and compares it against cond's
+// threshold, producing a single types.QualityGate result.
+func evaluateCondition(cond Condition, report *types.Report, diff types.ReportDiff) types.QualityGate {
+	name := fmt.Sprintf("%s %s %g", cond.Metric, cond.Op, cond.Value)
+	threshold := fmt.Sprintf("%s %g", cond.Op, cond.Value)
+
+	actual, err := metricValue(cond.Metric, report, diff)
+	if err != nil {
+		return types.QualityGate{Name: name, Condition: cond.Metric, Threshold: threshold, Passed: false, Message: err.Error()}
+	}
+
+	passed, err := compare(actual, cond.Op, cond.Value)
+	if err != nil {
+		return types.QualityGate{Name: name, Condition: cond.Metric, Threshold: threshold, Passed: false, Message: err.Error()}
+	}
+
+	return types.QualityGate{
+		Name:      name,
+		Condition: cond.Metric,
+		Threshold: threshold,
+		Passed:    passed,
+		Message:   fmt.Sprintf("actual %g", actual),
+	}
+}
+
+// metricValue resolves a condition's metric name to its current numeric
+// value. "new_"-prefixed metrics are counted over diff.New rather than read
+// off report.
+func metricValue(metric string, report *types.Report, diff types.ReportDiff) (float64, error) {
+	switch metric {
+	case "grade":
+		rank, ok := GradeRank(report.Grade)
+		if !ok {
+			return 0, fmt.Errorf("unrecognized grade %q", report.Grade)
+		}
+		return rank, nil
+	case "score":
+		return report.Score, nil
+	case "security_score":
+		return report.Trends.SecurityScore, nil
+	case "maintainability_index":
+		return report.Trends.MaintainabilityIndex, nil
+	case "technical_debt_ratio":
+		return report.Trends.TechnicalDebtRatio, nil
+	case "issues_density":
+		return report.Trends.IssuesDensity, nil
+	case "coverage":
+		return report.Metrics.TestCoverage, nil
+	case "new_issues":
+		return float64(len(diff.New)), nil
+	case "new_vulnerabilities":
+		return float64(countByType(diff.New, types.TypeVulnerability)), nil
+	case "new_bugs":
+		return float64(countByType(diff.New, types.TypeBug)), nil
+	case "new_high_severity":
+		return float64(countBySeverity(diff.New, types.SeverityHigh)), nil
+	case "high_severity":
+		return float64(report.IssuesSummary[types.SeverityHigh]), nil
+	case "medium_severity":
+		return float64(report.IssuesSummary[types.SeverityMedium]), nil
+	case "low_severity":
+		return float64(report.IssuesSummary[types.SeverityLow]), nil
+	case "vulnerabilities":
+		return float64(report.IssuesSummary[types.TypeVulnerability]), nil
+	case "bugs":
+		return float64(report.IssuesSummary[types.TypeBug]), nil
+	default:
+		return 0, fmt.Errorf("unknown metric %q", metric)
+	}
+}
+
+// gradeRank orders the letter grades calculateGrade produces so "grade"
+// can drive a ">=" Condition like every other metric; higher is better.
+var gradeRank = map[string]float64{
+	"A": 4,
+	"B": 3,
+	"C": 2,
+	"D": 1,
+	"F": 0,
+}
+
+// GradeRank returns grade's numeric rank for building a "grade" Condition
+// (e.g. GradeRank("C") as the Value of a ">=" condition enforces a C-or-better
+// floor), and false if grade isn't one of the letters calculateGrade assigns.
+func GradeRank(grade string) (float64, bool) {
+	rank, ok := gradeRank[grade]
+	return rank, ok
+}
+
+func countByType(issues []types.Issue, issueType string) int {
+	n := 0
+	for _, issue := range issues {
+		if issue.Type == issueType {
+			n++
+		}
+	}
+	return n
+}
+
+func countBySeverity(issues []types.Issue, severity string) int {
+	n := 0
+	for _, issue := range issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// compare applies op to actual and threshold.
+func compare(actual float64, op string, threshold float64) (bool, error) {
+	switch op {
+	case ">=":
+		return actual >= threshold, nil
+	case "<=":
+		return actual <= threshold, nil
+	case ">":
+		return actual > threshold, nil
+	case "<":
+		return actual < threshold, nil
+	case "==":
+		return actual == threshold, nil
+	case "!=":
+		return actual != threshold, nil
+	default:
+		return false, fmt.Errorf("unknown operator %q", op)
+	}
+}
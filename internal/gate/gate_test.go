@@ -0,0 +1,120 @@
+package gate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func TestLoadParsesConditions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "prod.yaml")
+	contents := `conditions:
+  - metric: security_score
+    op: ">="
+    value: 80
+  - metric: new_vulnerabilities
+    op: "=="
+    value: 0
+`
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write gate file: %v", err)
+	}
+
+	gate, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if len(gate.Conditions) != 2 {
+		t.Fatalf("len(Conditions) = %d, want 2", len(gate.Conditions))
+	}
+	if gate.Conditions[0] != (Condition{Metric: "security_score", Op: ">=", Value: 80}) {
+		t.Errorf("Conditions[0] = %+v", gate.Conditions[0])
+	}
+	if gate.Conditions[1] != (Condition{Metric: "new_vulnerabilities", Op: "==", Value: 0}) {
+		t.Errorf("Conditions[1] = %+v", gate.Conditions[1])
+	}
+}
+
+func TestLoadRejectsUnknownField(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad.yaml")
+	contents := "conditions:\n  - metric: score\n    threshold: 80\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write gate file: %v", err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Error("Load() expected an error for unknown field")
+	}
+}
+
+func TestEvaluatePassesAndFails(t *testing.T) {
+	gate := &Gate{Conditions: []Condition{
+		{Metric: "security_score", Op: ">=", Value: 80},
+		{Metric: "coverage", Op: ">=", Value: 90},
+	}}
+	report := &types.Report{
+		Trends:  types.QualityTrends{SecurityScore: 85},
+		Metrics: types.Metrics{TestCoverage: 60},
+	}
+
+	results := Evaluate(gate, report, nil)
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(results))
+	}
+	if !results[0].Passed {
+		t.Errorf("results[0].Passed = false, want true (%s)", results[0].Message)
+	}
+	if results[1].Passed {
+		t.Errorf("results[1].Passed = true, want false (%s)", results[1].Message)
+	}
+}
+
+func TestEvaluateNewVulnerabilitiesAgainstBaseline(t *testing.T) {
+	gate := &Gate{Conditions: []Condition{
+		{Metric: "new_vulnerabilities", Op: "==", Value: 0},
+	}}
+	baseline := &types.Report{Issues: []types.Issue{
+		{Type: types.TypeVulnerability, Fingerprint: "old-vuln"},
+	}}
+	report := &types.Report{Issues: []types.Issue{
+		{Type: types.TypeVulnerability, Fingerprint: "old-vuln"},
+		{Type: types.TypeVulnerability, Fingerprint: "new-vuln"},
+	}}
+
+	results := Evaluate(gate, report, baseline)
+	if results[0].Passed {
+		t.Errorf("expected gate to fail: one new vulnerability introduced, got %s", results[0].Message)
+	}
+}
+
+func TestEvaluateUnknownMetricFails(t *testing.T) {
+	gate := &Gate{Conditions: []Condition{{Metric: "bogus", Op: ">=", Value: 0}}}
+	report := &types.Report{}
+
+	results := Evaluate(gate, report, nil)
+	if results[0].Passed {
+		t.Error("expected gate with unknown metric to fail, not pass")
+	}
+}
+
+func TestEvaluateGradeFloor(t *testing.T) {
+	bRank, ok := GradeRank("B")
+	if !ok {
+		t.Fatal("GradeRank(\"B\") ok = false, want true")
+	}
+	gate := &Gate{Conditions: []Condition{{Metric: "grade", Op: ">=", Value: bRank}}}
+
+	results := Evaluate(gate, &types.Report{Grade: "A"}, nil)
+	if !results[0].Passed {
+		t.Errorf("grade A against a B floor: Passed = false, want true (%s)", results[0].Message)
+	}
+
+	results = Evaluate(gate, &types.Report{Grade: "C"}, nil)
+	if results[0].Passed {
+		t.Errorf("grade C against a B floor: Passed = true, want false (%s)", results[0].Message)
+	}
+}
@@ -0,0 +1,134 @@
+package gate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseYAML decodes the fixed "conditions: [{metric, op, value}, ...]" shape
+// a gate file uses. It's a hand-rolled subset of YAML rather than a vendored
+// decoder, for the same reason config.parseYAML and ignore.parseYAML avoid
+// pulling in a third-party module: this tree has no go.mod to add one to.
+func parseYAML(data []byte) (*Gate, error) {
+	gate := &Gate{}
+
+	var current *Condition
+	inConditions := false
+
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		if current.Metric == "" || current.Op == "" {
+			return fmt.Errorf("condition missing metric or op")
+		}
+		gate.Conditions = append(gate.Conditions, *current)
+		current = nil
+		return nil
+	}
+
+	for lineNum, raw := range strings.Split(string(data), "\n") {
+		line := stripComment(raw)
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum+1, err)
+			}
+			if strings.TrimSpace(line) != "conditions:" {
+				return nil, fmt.Errorf("line %d: expected \"conditions:\"", lineNum+1)
+			}
+			inConditions = true
+			continue
+		}
+
+		if !inConditions {
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(trimmed, "- ") {
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum+1, err)
+			}
+			current = &Condition{}
+			trimmed = strings.TrimPrefix(trimmed, "- ")
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: conditions entry missing leading \"- \"", lineNum+1)
+		}
+
+		key, value, ok := splitKeyValue(trimmed)
+		if !ok {
+			continue
+		}
+		value = unquote(value)
+
+		switch key {
+		case "metric":
+			current.Metric = value
+		case "op":
+			current.Op = value
+		case "value":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: value %q must be a number: %w", lineNum+1, value, err)
+			}
+			current.Value = n
+		default:
+			return nil, fmt.Errorf("line %d: unknown condition field %q", lineNum+1, key)
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return gate, nil
+}
+
+// stripComment removes a trailing "# ..." comment, ignoring '#' inside a
+// quoted string.
+func stripComment(line string) string {
+	inSingle, inDouble := false, false
+	for i, c := range line {
+		switch c {
+		case '\'':
+			if !inDouble {
+				inSingle = !inSingle
+			}
+		case '"':
+			if !inSingle {
+				inDouble = !inDouble
+			}
+		case '#':
+			if !inSingle && !inDouble && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// splitKeyValue splits a "key: value" line.
+func splitKeyValue(line string) (key, value string, ok bool) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return "", "", false
+	}
+	key = strings.TrimSpace(line[:idx])
+	value = strings.TrimSpace(line[idx+1:])
+	return key, value, key != ""
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
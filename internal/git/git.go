@@ -0,0 +1,207 @@
+// Package git shells out to the local git CLI to derive ownership and churn
+// signals (commit counts, author counts, line churn, blame) that the
+// analyzer combines with static metrics to rank files by risk.
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileChurn summarizes a file's recent commit history.
+type FileChurn struct {
+	File         string
+	CommitCount  int
+	Authors      map[string]bool
+	LinesAdded   int
+	LinesDeleted int
+	LastModified time.Time
+}
+
+// Churn runs `git log --numstat` over projectPath for commits in the last
+// `since` duration and returns per-file churn statistics keyed by the
+// file's path relative to the repository root. A file with no commits in
+// the window is simply absent from the result.
+func Churn(projectPath string, since time.Duration) (map[string]*FileChurn, error) {
+	cutoff := time.Now().Add(-since).Format("2006-01-02")
+
+	cmd := exec.Command("git", "log", "--since="+cutoff, "--numstat", "--pretty=format:commit|%H|%an|%at")
+	cmd.Dir = projectPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	result := make(map[string]*FileChurn)
+	var author string
+	var commitTime time.Time
+
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "commit|") {
+			parts := strings.SplitN(line, "|", 4)
+			if len(parts) < 4 {
+				continue
+			}
+			author = parts[2]
+			if ts, err := strconv.ParseInt(parts[3], 10, 64); err == nil {
+				commitTime = time.Unix(ts, 0)
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+
+		added, _ := strconv.Atoi(fields[0])   // "-" for binary files, treated as 0
+		deleted, _ := strconv.Atoi(fields[1]) // "-" for binary files, treated as 0
+		file := fields[2]
+
+		fc, ok := result[file]
+		if !ok {
+			fc = &FileChurn{File: file, Authors: make(map[string]bool)}
+			result[file] = fc
+		}
+
+		fc.CommitCount++
+		fc.Authors[author] = true
+		fc.LinesAdded += added
+		fc.LinesDeleted += deleted
+		if commitTime.After(fc.LastModified) {
+			fc.LastModified = commitTime
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+// ChangedFiles returns the paths (relative to the repository root) that
+// differ from ref, via `git diff --name-only`. Two values are treated as
+// keywords rather than a ref, matching the vocabulary the --diff flag
+// already documents: "staged" diffs the index against HEAD (git diff
+// --cached), and "unstaged" diffs the working tree against the index (plain
+// git diff, the default when no ref is given).
+func ChangedFiles(projectPath, ref string) ([]string, error) {
+	args := []string{"diff", "--name-only"}
+	switch ref {
+	case "staged":
+		args = append(args, "--cached")
+	case "unstaged":
+		// git diff with no ref already compares the working tree to the index
+	default:
+		args = append(args, ref)
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = projectPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %w", err)
+	}
+
+	var files []string
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			files = append(files, line)
+		}
+	}
+
+	return files, scanner.Err()
+}
+
+// ChangedLines returns the set of line numbers (1-indexed, in file's new
+// version) that differ from ref, via `git diff -U0`. file is relative to
+// projectPath, matching the paths ChangedFiles returns. -U0 asks for zero
+// context lines, so the unified-diff hunk headers (@@ -a,b +c,d @@) enumerate
+// exactly the added/modified lines and nothing else - used by --diff-base to
+// flag only issues on lines a reviewer actually touched, rather than every
+// issue in a file that happens to have any change at all.
+func ChangedLines(projectPath, ref, file string) (map[int]bool, error) {
+	args := []string{"diff", "-U0"}
+	switch ref {
+	case "staged":
+		args = append(args, "--cached")
+	case "unstaged":
+		// git diff with no ref already compares the working tree to the index
+	default:
+		args = append(args, ref)
+	}
+	args = append(args, "--", file)
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = projectPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %w", err)
+	}
+
+	lines := make(map[int]bool)
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "@@ ") {
+			continue
+		}
+
+		// "@@ -a,b +c,d @@ ..." - we only need the new-file side, "+c,d".
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		newRange := strings.TrimPrefix(fields[2], "+")
+
+		start, count := 0, 1
+		if idx := strings.Index(newRange, ","); idx >= 0 {
+			start, _ = strconv.Atoi(newRange[:idx])
+			count, _ = strconv.Atoi(newRange[idx+1:])
+		} else {
+			start, _ = strconv.Atoi(newRange)
+		}
+
+		for i := 0; i < count; i++ {
+			lines[start+i] = true
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// BlameAuthor returns the author who last touched line (1-indexed) of file
+// via `git blame`, so an issue on that line can be attributed to them.
+func BlameAuthor(projectPath, file string, line int) (string, error) {
+	if line < 1 {
+		return "", fmt.Errorf("invalid line number %d", line)
+	}
+
+	lineRange := fmt.Sprintf("%d,%d", line, line)
+	cmd := exec.Command("git", "blame", "-L", lineRange, "--porcelain", "--", file)
+	cmd.Dir = projectPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git blame failed: %w", err)
+	}
+
+	for _, l := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(l, "author ") {
+			return strings.TrimPrefix(l, "author "), nil
+		}
+	}
+
+	return "", fmt.Errorf("could not determine author for %s:%d", file, line)
+}
@@ -0,0 +1,162 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func initRepo(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test Author", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test Author", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test Author")
+
+	file := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(file, []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	run("add", ".")
+	run("commit", "-q", "-m", "initial commit")
+
+	if err := os.WriteFile(file, []byte("package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n"), 0644); err != nil {
+		t.Fatalf("failed to rewrite file: %v", err)
+	}
+	run("commit", "-q", "-am", "add greeting")
+
+	return dir
+}
+
+func TestChurn(t *testing.T) {
+	dir := initRepo(t)
+
+	churn, err := Churn(dir, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Churn() failed: %v", err)
+	}
+
+	fc, ok := churn["main.go"]
+	if !ok {
+		t.Fatal("Churn() has no entry for main.go")
+	}
+	if fc.CommitCount != 2 {
+		t.Errorf("CommitCount = %d, want 2", fc.CommitCount)
+	}
+	if len(fc.Authors) != 1 {
+		t.Errorf("len(Authors) = %d, want 1", len(fc.Authors))
+	}
+	if fc.LinesAdded == 0 {
+		t.Error("LinesAdded = 0, want > 0")
+	}
+}
+
+func TestChurnOutsideWindowIsEmpty(t *testing.T) {
+	dir := initRepo(t)
+
+	churn, err := Churn(dir, -24*time.Hour)
+	if err != nil {
+		t.Fatalf("Churn() failed: %v", err)
+	}
+	if len(churn) != 0 {
+		t.Errorf("Churn() with a window in the past returned %d files, want 0", len(churn))
+	}
+}
+
+func TestBlameAuthor(t *testing.T) {
+	dir := initRepo(t)
+
+	author, err := BlameAuthor(dir, "main.go", 4)
+	if err != nil {
+		t.Fatalf("BlameAuthor() failed: %v", err)
+	}
+	if author != "Test Author" {
+		t.Errorf("BlameAuthor() = %q, want Test Author", author)
+	}
+}
+
+func TestBlameAuthorInvalidLine(t *testing.T) {
+	dir := initRepo(t)
+
+	if _, err := BlameAuthor(dir, "main.go", 0); err == nil {
+		t.Error("BlameAuthor() expected an error for line 0")
+	}
+}
+
+func TestChangedFilesAgainstRef(t *testing.T) {
+	dir := initRepo(t)
+
+	files, err := ChangedFiles(dir, "HEAD~1")
+	if err != nil {
+		t.Fatalf("ChangedFiles() failed: %v", err)
+	}
+	if len(files) != 1 || files[0] != "main.go" {
+		t.Errorf("ChangedFiles() = %v, want [main.go]", files)
+	}
+}
+
+func TestChangedLinesOnlyReportsAddedLines(t *testing.T) {
+	dir := initRepo(t)
+
+	lines, err := ChangedLines(dir, "HEAD~1", "main.go")
+	if err != nil {
+		t.Fatalf("ChangedLines() failed: %v", err)
+	}
+
+	// initRepo's second commit replaces "func main() {}" with the 3 lines
+	// "func main() {" / "\tprintln(\"hi\")" / "}", so the new file's changed
+	// range is lines 3-5.
+	want := map[int]bool{3: true, 4: true, 5: true}
+	if len(lines) != len(want) {
+		t.Fatalf("ChangedLines() = %v, want %v", lines, want)
+	}
+	for l := range want {
+		if !lines[l] {
+			t.Errorf("ChangedLines() missing line %d: %v", l, lines)
+		}
+	}
+}
+
+func TestChangedFilesStagedAndUnstaged(t *testing.T) {
+	dir := initRepo(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {\n\tprintln(\"bye\")\n}\n"), 0644); err != nil {
+		t.Fatalf("failed to rewrite file: %v", err)
+	}
+
+	unstaged, err := ChangedFiles(dir, "unstaged")
+	if err != nil {
+		t.Fatalf("ChangedFiles(unstaged) failed: %v", err)
+	}
+	if len(unstaged) != 1 || unstaged[0] != "main.go" {
+		t.Errorf("ChangedFiles(unstaged) = %v, want [main.go]", unstaged)
+	}
+
+	add := exec.Command("git", "add", ".")
+	add.Dir = dir
+	if out, err := add.CombinedOutput(); err != nil {
+		t.Fatalf("git add failed: %v\n%s", err, out)
+	}
+
+	staged, err := ChangedFiles(dir, "staged")
+	if err != nil {
+		t.Fatalf("ChangedFiles(staged) failed: %v", err)
+	}
+	if len(staged) != 1 || staged[0] != "main.go" {
+		t.Errorf("ChangedFiles(staged) = %v, want [main.go]", staged)
+	}
+}
@@ -0,0 +1,154 @@
+// Package ignore implements the .nadaignore checksum baseline: a file
+// listing, per already-triaged file, the sha256 of its contents at baseline
+// time and the rule IDs to suppress there. It's the same shape secret
+// scanners' fileignoreconfig uses to onboard a tool onto a legacy codebase
+// without a one-shot flood of findings, while still resurfacing an issue
+// the moment the file it lives in actually changes.
+package ignore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// DefaultFileName is the conventional baseline path, resolved relative to
+// the project root the same way rules.LoadRulePackDir resolves "rules/".
+const DefaultFileName = ".nadaignore"
+
+// Entry suppresses IgnoreRules in File, as long as its sha256 content
+// checksum still matches Checksum. Once the file's contents drift from
+// Checksum, its issues resurface (marked stale) instead of staying hidden.
+type Entry struct {
+	Filename    string
+	Checksum    string
+	IgnoreRules []string
+}
+
+// File is the parsed .nadaignore: one Entry per file that had issues when
+// the baseline was captured.
+type File struct {
+	Entries []Entry
+}
+
+func (f *File) entry(filename string) (Entry, bool) {
+	if f == nil {
+		return Entry{}, false
+	}
+	for _, e := range f.Entries {
+		if e.Filename == filename {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Checksum returns the sha256 hex digest of content, the same value stored
+// in an Entry's Checksum field.
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Apply filters issues found in filename (whose current content hashes to
+// contentHash) against f: an issue is dropped if filename has a baseline
+// entry whose checksum matches and whose IgnoreRules lists the issue's
+// Rule. If filename has an entry but the checksum no longer matches, issues
+// that would otherwise be suppressed are kept and flagged BaselineStale so
+// reviewers know to regenerate the baseline with `nada baseline update`.
+func (f *File) Apply(filename, contentHash string, issues []types.Issue) []types.Issue {
+	entry, ok := f.entry(filename)
+	if !ok {
+		return issues
+	}
+
+	ignored := make(map[string]bool, len(entry.IgnoreRules))
+	for _, rule := range entry.IgnoreRules {
+		ignored[rule] = true
+	}
+	stale := entry.Checksum != contentHash
+
+	kept := make([]types.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !ignored[issue.Rule] {
+			kept = append(kept, issue)
+			continue
+		}
+		if stale {
+			issue.BaselineStale = true
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// BuildFromReport captures a new baseline from report: one Entry per file
+// that has at least one issue, with Checksum read fresh from disk (not from
+// the report, which doesn't carry per-file hashes) and IgnoreRules set to
+// every rule ID that fired in that file.
+func BuildFromReport(report *types.Report) (*File, error) {
+	order := make([]string, 0)
+	rulesByFile := make(map[string]map[string]bool)
+
+	for _, issue := range report.Issues {
+		if issue.File == "" || issue.Rule == "" {
+			continue
+		}
+		if _, ok := rulesByFile[issue.File]; !ok {
+			rulesByFile[issue.File] = make(map[string]bool)
+			order = append(order, issue.File)
+		}
+		rulesByFile[issue.File][issue.Rule] = true
+	}
+
+	sort.Strings(order)
+
+	file := &File{Entries: make([]Entry, 0, len(order))}
+	for _, filename := range order {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to checksum %s: %w", filename, err)
+		}
+
+		rules := make([]string, 0, len(rulesByFile[filename]))
+		for rule := range rulesByFile[filename] {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+
+		file.Entries = append(file.Entries, Entry{
+			Filename:    filename,
+			Checksum:    Checksum(content),
+			IgnoreRules: rules,
+		})
+	}
+
+	return file, nil
+}
+
+// Load reads the .nadaignore at path. A missing file is not an error and
+// yields an empty *File, since most projects won't have one yet.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &File{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parseYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Save writes file to path in the shape Load reads back.
+func Save(file *File, path string) error {
+	return os.WriteFile(path, marshalYAML(file), 0644)
+}
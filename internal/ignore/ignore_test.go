@@ -0,0 +1,113 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func TestApplySuppressesMatchingChecksum(t *testing.T) {
+	file := &File{Entries: []Entry{
+		{Filename: "main.go", Checksum: "abc", IgnoreRules: []string{"long_line"}},
+	}}
+
+	issues := []types.Issue{
+		{File: "main.go", Rule: "long_line"},
+		{File: "main.go", Rule: "high_complexity"},
+	}
+
+	got := file.Apply("main.go", "abc", issues)
+	if len(got) != 1 || got[0].Rule != "high_complexity" {
+		t.Fatalf("Apply() = %+v, want only the non-ignored high_complexity issue", got)
+	}
+}
+
+func TestApplyResurfacesStaleChecksum(t *testing.T) {
+	file := &File{Entries: []Entry{
+		{Filename: "main.go", Checksum: "abc", IgnoreRules: []string{"long_line"}},
+	}}
+
+	issues := []types.Issue{{File: "main.go", Rule: "long_line"}}
+
+	got := file.Apply("main.go", "def", issues)
+	if len(got) != 1 || !got[0].BaselineStale {
+		t.Fatalf("Apply() = %+v, want the issue to resurface marked BaselineStale", got)
+	}
+}
+
+func TestApplyNoEntryIsNoop(t *testing.T) {
+	file := &File{}
+	issues := []types.Issue{{File: "main.go", Rule: "long_line"}}
+
+	got := file.Apply("main.go", "abc", issues)
+	if len(got) != 1 {
+		t.Fatalf("Apply() = %+v, want the issue unchanged when there's no baseline entry", got)
+	}
+}
+
+func TestLoadAndSaveRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".nadaignore")
+
+	original := &File{Entries: []Entry{
+		{Filename: "main.go", Checksum: "abc123", IgnoreRules: []string{"long_line", "high_complexity"}},
+	}}
+
+	if err := Save(original, path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(loaded.Entries) != 1 {
+		t.Fatalf("Load().Entries = %d entries, want 1", len(loaded.Entries))
+	}
+	entry := loaded.Entries[0]
+	if entry.Filename != "main.go" || entry.Checksum != "abc123" || len(entry.IgnoreRules) != 2 {
+		t.Errorf("Load().Entries[0] = %+v, unexpected", entry)
+	}
+}
+
+func TestLoadMissingFileIsEmpty(t *testing.T) {
+	file, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil for a missing file", err)
+	}
+	if len(file.Entries) != 0 {
+		t.Errorf("Load() = %+v, want empty entries for a missing file", file)
+	}
+}
+
+func TestBuildFromReport(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(filename, []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	report := &types.Report{Issues: []types.Issue{
+		{File: filename, Rule: "long_line"},
+		{File: filename, Rule: "high_complexity"},
+	}}
+
+	baseline, err := BuildFromReport(report)
+	if err != nil {
+		t.Fatalf("BuildFromReport() error = %v", err)
+	}
+
+	if len(baseline.Entries) != 1 {
+		t.Fatalf("BuildFromReport().Entries = %d entries, want 1", len(baseline.Entries))
+	}
+	entry := baseline.Entries[0]
+	if entry.Filename != filename || len(entry.IgnoreRules) != 2 {
+		t.Errorf("BuildFromReport().Entries[0] = %+v, unexpected", entry)
+	}
+	if entry.Checksum != Checksum([]byte("package main\n")) {
+		t.Errorf("BuildFromReport().Entries[0].Checksum = %v, want checksum of file contents", entry.Checksum)
+	}
+}
@@ -0,0 +1,152 @@
+package ignore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseYAML decodes the fixed "entries: [{filename, checksum, ignore_rules:
+// [...]}, ...]" shape a .nadaignore file uses. It's a hand-rolled subset of
+// YAML rather than a vendored decoder, for the same reason
+// rules.parseRulePackYAML and config.parseYAML avoid pulling in a
+// third-party module: this tree has no go.mod to add one to.
+func parseYAML(data []byte) (*File, error) {
+	file := &File{}
+	var current *Entry
+	inEntries := false
+	inIgnoreRules := false
+	// entryIndent is the leading-whitespace width of the "- " that starts
+	// an entries item (e.g. 2, for "  - filename: ..."); an ignore_rules
+	// item is indented deeper than that ("      - long_line"). Without
+	// this, both look like a bare "- " line and an ignore_rules item
+	// would be misread as the start of a new, bogus entry.
+	entryIndent := -1
+
+	flush := func() {
+		if current != nil {
+			file.Entries = append(file.Entries, *current)
+			current = nil
+		}
+	}
+
+	for lineNum, raw := range strings.Split(string(data), "\n") {
+		line := stripComment(raw)
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			flush()
+			inIgnoreRules = false
+			if strings.TrimSpace(line) != "entries:" {
+				return nil, fmt.Errorf("line %d: expected \"entries:\"", lineNum+1)
+			}
+			inEntries = true
+			continue
+		}
+
+		if !inEntries {
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := len(line) - len(trimmed)
+
+		if inIgnoreRules && strings.HasPrefix(trimmed, "- ") && indent > entryIndent {
+			current.IgnoreRules = append(current.IgnoreRules, unquote(strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))))
+			continue
+		}
+		inIgnoreRules = false
+
+		if strings.HasPrefix(trimmed, "- ") {
+			flush()
+			current = &Entry{}
+			entryIndent = indent
+			trimmed = strings.TrimPrefix(trimmed, "- ")
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: entries item missing leading \"- \"", lineNum+1)
+		}
+
+		key, value, ok := splitKeyValue(trimmed)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "filename":
+			current.Filename = unquote(value)
+		case "checksum":
+			current.Checksum = unquote(value)
+		case "ignore_rules":
+			inIgnoreRules = true
+		default:
+			return nil, fmt.Errorf("line %d: unknown entries field %q", lineNum+1, key)
+		}
+	}
+	flush()
+
+	return file, nil
+}
+
+// stripComment removes a trailing "# ..." comment, ignoring '#' inside a
+// quoted string.
+func stripComment(line string) string {
+	inSingle, inDouble := false, false
+	for i, c := range line {
+		switch c {
+		case '\'':
+			if !inDouble {
+				inSingle = !inSingle
+			}
+		case '"':
+			if !inSingle {
+				inDouble = !inDouble
+			}
+		case '#':
+			if !inSingle && !inDouble && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// splitKeyValue splits a "key: value" line (value may be empty, for a key
+// whose content is on following indented lines, like ignore_rules).
+func splitKeyValue(line string) (key, value string, ok bool) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return "", "", false
+	}
+	key = strings.TrimSpace(line[:idx])
+	value = strings.TrimSpace(line[idx+1:])
+	return key, value, key != ""
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// marshalYAML renders file in the shape parseYAML reads back.
+func marshalYAML(file *File) []byte {
+	var b strings.Builder
+
+	b.WriteString("entries:\n")
+	for _, e := range file.Entries {
+		fmt.Fprintf(&b, "  - filename: %s\n", e.Filename)
+		fmt.Fprintf(&b, "    checksum: %s\n", e.Checksum)
+		if len(e.IgnoreRules) > 0 {
+			b.WriteString("    ignore_rules:\n")
+			for _, rule := range e.IgnoreRules {
+				fmt.Fprintf(&b, "      - %s\n", rule)
+			}
+		}
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,100 @@
+// Package halstead computes Halstead complexity metrics for a piece of Go
+// source, following Maurice Halstead's original operator/operand model:
+// distinct operators (n1) and operands (n2), their total occurrences
+// (N1, N2), and the resulting program volume.
+package halstead
+
+import (
+	"go/ast"
+	"math"
+)
+
+// Metrics holds the raw operator/operand counts for a piece of code along
+// with the derived Halstead Volume.
+type Metrics struct {
+	DistinctOperators int     `json:"distinct_operators"`
+	DistinctOperands  int     `json:"distinct_operands"`
+	TotalOperators    int     `json:"total_operators"`
+	TotalOperands     int     `json:"total_operands"`
+	Volume            float64 `json:"volume"`
+}
+
+// Analyze walks node and tallies its operators and operands, returning the
+// resulting Metrics. Operators are binary/unary/assignment tokens, control
+// flow keywords (if, for, switch, ...) and calls; operands are identifiers
+// and literals.
+func Analyze(node ast.Node) Metrics {
+	operators := make(map[string]int)
+	operands := make(map[string]int)
+	totalOperators := 0
+	totalOperands := 0
+
+	addOperator := func(token string) {
+		operators[token]++
+		totalOperators++
+	}
+	addOperand := func(token string) {
+		operands[token]++
+		totalOperands++
+	}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.BinaryExpr:
+			addOperator(x.Op.String())
+		case *ast.UnaryExpr:
+			addOperator(x.Op.String())
+		case *ast.AssignStmt:
+			addOperator(x.Tok.String())
+		case *ast.IncDecStmt:
+			addOperator(x.Tok.String())
+		case *ast.CallExpr:
+			addOperator("call")
+		case *ast.IfStmt:
+			addOperator("if")
+		case *ast.ForStmt:
+			addOperator("for")
+		case *ast.RangeStmt:
+			addOperator("range")
+		case *ast.SwitchStmt:
+			addOperator("switch")
+		case *ast.TypeSwitchStmt:
+			addOperator("typeswitch")
+		case *ast.SelectStmt:
+			addOperator("select")
+		case *ast.ReturnStmt:
+			addOperator("return")
+		case *ast.BranchStmt:
+			addOperator(x.Tok.String())
+		case *ast.GoStmt:
+			addOperator("go")
+		case *ast.DeferStmt:
+			addOperator("defer")
+		case *ast.SendStmt:
+			addOperator("<-")
+		case *ast.Ident:
+			addOperand(x.Name)
+		case *ast.BasicLit:
+			addOperand(x.Value)
+		}
+		return true
+	})
+
+	n1 := len(operators)
+	n2 := len(operands)
+	vocabulary := n1 + n2
+	length := totalOperators + totalOperands
+
+	var volume float64
+	if vocabulary > 1 {
+		volume = float64(length) * math.Log2(float64(vocabulary))
+	}
+
+	return Metrics{
+		DistinctOperators: n1,
+		DistinctOperands:  n2,
+		TotalOperators:    totalOperators,
+		TotalOperands:     totalOperands,
+		Volume:            volume,
+	}
+}
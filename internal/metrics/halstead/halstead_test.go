@@ -0,0 +1,86 @@
+package halstead
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func parseFunc(t *testing.T, src string) *ast.FuncDecl {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", "package p\n"+src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			return fn
+		}
+	}
+
+	t.Fatal("no function declaration found in source")
+	return nil
+}
+
+func TestAnalyzeSimpleFunction(t *testing.T) {
+	fn := parseFunc(t, `
+func add(a, b int) int {
+	return a + b
+}`)
+
+	m := Analyze(fn.Body)
+
+	if m.TotalOperators == 0 {
+		t.Errorf("Analyze().TotalOperators = 0, want > 0")
+	}
+	if m.TotalOperands == 0 {
+		t.Errorf("Analyze().TotalOperands = 0, want > 0")
+	}
+	if m.Volume <= 0 {
+		t.Errorf("Analyze().Volume = %v, want > 0", m.Volume)
+	}
+}
+
+func TestAnalyzeMoreComplexFunctionHasLargerVolume(t *testing.T) {
+	simple := parseFunc(t, `
+func simple() int {
+	return 1
+}`)
+
+	complex := parseFunc(t, `
+func complex(items []int) int {
+	total := 0
+	for i, v := range items {
+		if v > 0 {
+			total += v * i
+		} else {
+			total -= v
+		}
+	}
+	return total
+}`)
+
+	simpleMetrics := Analyze(simple.Body)
+	complexMetrics := Analyze(complex.Body)
+
+	if complexMetrics.Volume <= simpleMetrics.Volume {
+		t.Errorf("Analyze().Volume = %v for complex function, want > %v (simple function)",
+			complexMetrics.Volume, simpleMetrics.Volume)
+	}
+}
+
+func TestAnalyzeEmptyBodyHasZeroVolume(t *testing.T) {
+	fn := parseFunc(t, `
+func empty() {
+}`)
+
+	m := Analyze(fn.Body)
+
+	if m.Volume != 0 {
+		t.Errorf("Analyze().Volume = %v, want 0 for an empty function body", m.Volume)
+	}
+}
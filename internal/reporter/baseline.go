@@ -0,0 +1,39 @@
+package reporter
+
+import "github.com/chaksack/nada/internal/types"
+
+// DiffAgainstBaseline compares report against baseline and returns a copy of
+// report whose Issues are tagged IsNew and whose IssuesSummary counts only
+// the newly introduced issues, so downstream quality gates ratchet on
+// regressions instead of pre-existing debt. The full types.ReportDiff is
+// returned alongside it for callers that also want fixed/unchanged counts.
+//
+// If baseline is nil, report is returned unchanged and the diff is zero-valued.
+func DiffAgainstBaseline(report *types.Report, baseline *types.Report) (*types.Report, types.ReportDiff) {
+	if baseline == nil {
+		return report, types.ReportDiff{}
+	}
+
+	diff := report.Diff(baseline)
+	newFingerprints := make(map[string]bool, len(diff.New))
+	for _, issue := range diff.New {
+		if issue.Fingerprint != "" {
+			newFingerprints[issue.Fingerprint] = true
+		}
+	}
+
+	tagged := *report
+	tagged.Issues = make([]types.Issue, len(report.Issues))
+	summary := make(map[string]int, len(report.IssuesSummary))
+	for i, issue := range report.Issues {
+		issue.IsNew = issue.Fingerprint == "" || newFingerprints[issue.Fingerprint]
+		if issue.IsNew {
+			summary[issue.Severity]++
+			summary[issue.Type]++
+		}
+		tagged.Issues[i] = issue
+	}
+	tagged.IssuesSummary = summary
+
+	return &tagged, diff
+}
@@ -0,0 +1,57 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func TestDiffAgainstBaselineNil(t *testing.T) {
+	report := testReport()
+
+	got, diff := DiffAgainstBaseline(report, nil)
+	if got != report {
+		t.Errorf("DiffAgainstBaseline(nil) returned a different report, want the same pointer")
+	}
+	if len(diff.New) != 0 || len(diff.Fixed) != 0 || len(diff.Unchanged) != 0 {
+		t.Errorf("DiffAgainstBaseline(nil) diff = %+v, want zero value", diff)
+	}
+}
+
+func TestDiffAgainstBaseline(t *testing.T) {
+	baseline := &types.Report{
+		Issues: []types.Issue{
+			{Severity: types.SeverityHigh, Type: types.TypeBug, Fingerprint: "fp-carried"},
+		},
+	}
+
+	report := &types.Report{
+		IssuesSummary: map[string]int{types.SeverityHigh: 2, types.TypeBug: 1, types.TypeCodeSmell: 1},
+		Issues: []types.Issue{
+			{Severity: types.SeverityHigh, Type: types.TypeBug, Fingerprint: "fp-carried"},
+			{Severity: types.SeverityHigh, Type: types.TypeCodeSmell, Fingerprint: "fp-new"},
+		},
+	}
+
+	got, diff := DiffAgainstBaseline(report, baseline)
+	if len(diff.New) != 1 || len(diff.Unchanged) != 1 {
+		t.Fatalf("DiffAgainstBaseline() diff = %+v, want one new and one unchanged", diff)
+	}
+
+	if got.Issues[0].IsNew {
+		t.Errorf("got.Issues[0].IsNew = true, want false for the carried-over issue")
+	}
+	if !got.Issues[1].IsNew {
+		t.Errorf("got.Issues[1].IsNew = false, want true for the new issue")
+	}
+
+	if got.IssuesSummary[types.SeverityHigh] != 1 {
+		t.Errorf("got.IssuesSummary[%q] = %d, want 1 (only the new issue)", types.SeverityHigh, got.IssuesSummary[types.SeverityHigh])
+	}
+	if got.IssuesSummary[types.TypeBug] != 0 {
+		t.Errorf("got.IssuesSummary[%q] = %d, want 0 (carried over, not new)", types.TypeBug, got.IssuesSummary[types.TypeBug])
+	}
+	if got.IssuesSummary[types.TypeCodeSmell] != 1 {
+		t.Errorf("got.IssuesSummary[%q] = %d, want 1", types.TypeCodeSmell, got.IssuesSummary[types.TypeCodeSmell])
+	}
+}
@@ -0,0 +1,91 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// codeClimateIssue matches the subset of the Code Climate spec
+// (https://github.com/codeclimate/platform/blob/master/spec/analyzers/SPEC.md)
+// that GitLab's Code Quality widget understands.
+type codeClimateIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Categories  []string            `json:"categories"`
+	Severity    string              `json:"severity"`
+	Fingerprint string              `json:"fingerprint"`
+	Location    codeClimateLocation `json:"location"`
+}
+
+type codeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines codeClimateLines `json:"lines"`
+}
+
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+}
+
+// WriteCodeClimateReport emits report as a Code Climate JSON array, the
+// format GitLab CI's "Code Quality" merge-request widget consumes.
+func WriteCodeClimateReport(report *types.Report, w io.Writer) error {
+	issues := make([]codeClimateIssue, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		issues = append(issues, codeClimateIssue{
+			Type:        "issue",
+			CheckName:   issue.Rule,
+			Description: issue.Message,
+			Categories:  []string{codeClimateCategory(issue.Type)},
+			Severity:    codeClimateSeverity(issue.Severity),
+			Fingerprint: codeClimateFingerprint(issue),
+			Location: codeClimateLocation{
+				Path:  issue.File,
+				Lines: codeClimateLines{Begin: issue.Line},
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
+
+// codeClimateSeverity maps a types.Severity to a Code Climate severity.
+func codeClimateSeverity(severity string) string {
+	switch severity {
+	case types.SeverityHigh:
+		return "blocker"
+	case types.SeverityMedium:
+		return "major"
+	case types.SeverityLow:
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// codeClimateCategory maps a types.Issue type to a Code Climate category.
+func codeClimateCategory(issueType string) string {
+	switch issueType {
+	case types.TypeVulnerability:
+		return "Security"
+	case types.TypeBug, types.TypeError:
+		return "Bug Risk"
+	default:
+		return "Style"
+	}
+}
+
+// codeClimateFingerprint derives a stable fingerprint, matching the scheme
+// used for SARIF partialFingerprints so both report formats dedupe the same
+// finding identically.
+func codeClimateFingerprint(issue types.Issue) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s", issue.Rule, issue.File, issue.Message)))
+	return hex.EncodeToString(sum[:])
+}
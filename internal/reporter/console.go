@@ -39,6 +39,11 @@ func printMetrics(report *types.Report) {
 		fmt.Printf("   🔄 Avg Complexity: %.1f\n", avgComplexity)
 	}
 
+	if report.Metrics.CognitiveComplexity > 0 && report.FilesAnalyzed > 0 {
+		avgCognitive := float64(report.Metrics.CognitiveComplexity) / float64(report.FilesAnalyzed)
+		fmt.Printf("   🧠 Avg Cognitive Complexity: %.1f\n", avgCognitive)
+	}
+
 	fmt.Printf("   🧪 Test Coverage: %.1f%%\n", report.Metrics.TestCoverage)
 }
 
@@ -58,6 +63,10 @@ func printIssuesSummary(report *types.Report) {
 	fmt.Printf("   🐛 Bugs: %d\n", report.IssuesSummary[types.TypeBug])
 	fmt.Printf("   🔒 Vulnerabilities: %d\n", report.IssuesSummary[types.TypeVulnerability])
 	fmt.Printf("   💨 Code Smells: %d\n", report.IssuesSummary[types.TypeCodeSmell])
+
+	if report.UnusedSuppressions > 0 {
+		fmt.Printf("   🧹 Unused //nada:ignore directives: %d\n", report.UnusedSuppressions)
+	}
 }
 
 // printTopIssues prints the most critical issues
@@ -106,9 +115,16 @@ func printTopIssues(report *types.Report) {
 
 		// Show high priority issues
 		if issue.Severity == types.SeverityHigh || issue.Type == types.TypeVulnerability {
-			fmt.Printf("   %s:%d - %s [%s/%s]\n",
+			newTag := ""
+			if issue.IsNew {
+				newTag = " 🆕"
+			}
+			if issue.BaselineStale {
+				newTag += " ⚠️ baseline stale"
+			}
+			fmt.Printf("   %s:%d - %s [%s/%s]%s\n",
 				filepath.Base(issue.File), issue.Line, issue.Message,
-				getTypeEmoji(issue.Type), getSeverityEmoji(issue.Severity))
+				getTypeEmoji(issue.Type), getSeverityEmoji(issue.Severity), newTag)
 			count++
 		}
 	}
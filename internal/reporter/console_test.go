@@ -351,6 +351,39 @@ func TestPrintTopIssues(t *testing.T) {
 	}
 }
 
+func TestPrintTopIssuesTagsNewIssues(t *testing.T) {
+	report := &types.Report{
+		Issues: []types.Issue{
+			{
+				Type:     types.TypeVulnerability,
+				Severity: types.SeverityHigh,
+				File:     "/path/to/file.go",
+				Line:     42,
+				Message:  "SQL injection found",
+				IsNew:    true,
+			},
+			{
+				Type:     types.TypeBug,
+				Severity: types.SeverityHigh,
+				File:     "/path/to/another.go",
+				Line:     10,
+				Message:  "Null pointer dereference",
+			},
+		},
+	}
+
+	output := captureOutput(func() {
+		printTopIssues(report)
+	})
+
+	if !strings.Contains(output, "file.go:42 - SQL injection found [🔒/🔴] 🆕") {
+		t.Errorf("printTopIssues() output missing 🆕 tag for a new issue: %q", output)
+	}
+	if strings.Contains(output, "another.go:10 - Null pointer dereference [🐛/🔴] 🆕") {
+		t.Errorf("printTopIssues() tagged a carried-over issue as new: %q", output)
+	}
+}
+
 func TestPrintTopIssuesNoIssues(t *testing.T) {
 	report := &types.Report{
 		Issues: []types.Issue{},
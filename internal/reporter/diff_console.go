@@ -0,0 +1,31 @@
+package reporter
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// PrintDiffReport prints a baseline-relative summary: a one-line count of
+// new/fixed/unchanged issues, then every new issue in full (the regressions
+// a PR actually needs to address) with fixed/unchanged issues left out of
+// the detail list entirely, unlike PrintConsoleReport's top-issues view
+// which shows everything regardless of IsNew.
+func PrintDiffReport(diff types.ReportDiff) {
+	fmt.Println("\n📐 Baseline Diff")
+	fmt.Println("================")
+	fmt.Printf("🆕 New: %d   ✅ Fixed: %d   ⏸  Unchanged: %d\n", len(diff.New), len(diff.Fixed), len(diff.Unchanged))
+
+	if len(diff.New) == 0 {
+		fmt.Println("\n✅ No new issues introduced")
+		return
+	}
+
+	fmt.Println("\n⚠️  New Issues:")
+	for _, issue := range diff.New {
+		fmt.Printf("   %s:%d - %s [%s/%s]\n",
+			filepath.Base(issue.File), issue.Line, issue.Message,
+			getTypeEmoji(issue.Type), getSeverityEmoji(issue.Severity))
+	}
+}
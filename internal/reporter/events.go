@@ -0,0 +1,80 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// EventLog writes one JSON object per line (NDJSON) for each lifecycle event
+// of an AnalyzeProject run - analysis_started, file_analyzed, issue_found,
+// analyzer_error, analysis_finished - so external tooling can tail
+// --log-events and react in real time instead of waiting for the final
+// report. A mutex guards w: AnalysisStarted/AnalyzerError/AnalysisFinished
+// are called from the caller's goroutine around AnalyzeProject, while
+// FileAnalyzed is fed from Progress's own consumer goroutine (see
+// NewProgressWithEventLog), and those calls are never otherwise ordered
+// against each other.
+type EventLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// logEvent is one NDJSON line; omitempty keeps each event's line limited to
+// the fields that apply to its Type.
+type logEvent struct {
+	Type       string       `json:"type"`
+	Time       time.Time    `json:"time"`
+	File       string       `json:"file,omitempty"`
+	FilesDone  int          `json:"files_done,omitempty"`
+	FilesTotal int          `json:"files_total,omitempty"`
+	Issue      *types.Issue `json:"issue,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// NewEventLog wraps w for use by an AnalyzeProject run's lifecycle methods.
+func NewEventLog(w io.Writer) *EventLog {
+	return &EventLog{w: w}
+}
+
+func (e *EventLog) emit(evt logEvent) {
+	evt.Time = time.Now()
+
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	fmt.Fprintln(e.w, string(data))
+}
+
+// AnalysisStarted logs the run's start, before any file is analyzed.
+func (e *EventLog) AnalysisStarted() {
+	e.emit(logEvent{Type: "analysis_started"})
+}
+
+// FileAnalyzed logs progEvt's file finishing, plus one issue_found event per
+// issue it found.
+func (e *EventLog) FileAnalyzed(progEvt types.ProgressEvent) {
+	e.emit(logEvent{Type: "file_analyzed", File: progEvt.File, FilesDone: progEvt.FilesDone, FilesTotal: progEvt.FilesTotal})
+	for i := range progEvt.Issues {
+		issue := progEvt.Issues[i]
+		e.emit(logEvent{Type: "issue_found", File: issue.File, Issue: &issue})
+	}
+}
+
+// AnalyzerError logs a fatal error that aborted the run.
+func (e *EventLog) AnalyzerError(err error) {
+	e.emit(logEvent{Type: "analyzer_error", Error: err.Error()})
+}
+
+// AnalysisFinished logs the run's completion.
+func (e *EventLog) AnalysisFinished() {
+	e.emit(logEvent{Type: "analysis_finished"})
+}
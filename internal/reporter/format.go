@@ -0,0 +1,41 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/chaksack/nada/internal/rules"
+	"github.com/chaksack/nada/internal/types"
+)
+
+// WriteReport serializes report in the requested format and writes it to w.
+// engine may be nil for formats that don't need a rule catalog (json, junit,
+// codeclimate, sonarqube, markdown); SARIF uses it to populate
+// tool.driver.rules.
+func WriteReport(report *types.Report, engine *rules.Engine, format string, w io.Writer) error {
+	switch format {
+	case "", types.FormatJSON:
+		return writeJSONReport(report, w)
+	case types.FormatSARIF:
+		return WriteSARIFReport(report, engine, w)
+	case types.FormatJUnit:
+		return WriteJUnitReport(report, w)
+	case types.FormatCodeClimate:
+		return WriteCodeClimateReport(report, w)
+	case types.FormatSonarQube:
+		return WriteSonarQubeReport(report, w)
+	case types.FormatMarkdown:
+		return WriteMarkdownReport(report, w)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// writeJSONReport writes the report as indented JSON, matching the format
+// historically produced by the CLI's saveReport.
+func writeJSONReport(report *types.Report, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
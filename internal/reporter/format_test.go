@@ -0,0 +1,175 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/chaksack/nada/internal/rules"
+	"github.com/chaksack/nada/internal/types"
+)
+
+func testReport() *types.Report {
+	return &types.Report{
+		ProjectPath: "/test/project",
+		Grade:       "B",
+		Score:       85.0,
+		Issues: []types.Issue{
+			{
+				Type:     types.TypeVulnerability,
+				Severity: types.SeverityHigh,
+				File:     "/test/project/main.go",
+				Line:     10,
+				Column:   2,
+				Message:  "SQL injection vulnerability",
+				Rule:     "sql_injection",
+			},
+		},
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		wantErr bool
+	}{
+		{name: "default", format: "", wantErr: false},
+		{name: "json", format: types.FormatJSON, wantErr: false},
+		{name: "sarif", format: types.FormatSARIF, wantErr: false},
+		{name: "junit", format: types.FormatJUnit, wantErr: false},
+		{name: "codeclimate", format: types.FormatCodeClimate, wantErr: false},
+		{name: "unknown", format: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteReport(testReport(), rules.NewEngine(), tt.format, &buf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WriteReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && buf.Len() == 0 {
+				t.Errorf("WriteReport() wrote no output for format %q", tt.format)
+			}
+		})
+	}
+}
+
+func TestWriteSARIFReport(t *testing.T) {
+	engine := rules.NewEngine()
+
+	report := testReport()
+	report.Issues[0].Category = "security"
+
+	var buf bytes.Buffer
+	if err := WriteSARIFReport(report, engine, &buf); err != nil {
+		t.Fatalf("WriteSARIFReport() failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIFReport() produced invalid JSON: %v", err)
+	}
+
+	if len(log.Runs) != len(engine.GetRules()) {
+		t.Fatalf("expected one run per registered rule (%d), got %d", len(engine.GetRules()), len(log.Runs))
+	}
+
+	var securityRun *sarifRun
+	for i := range log.Runs {
+		if log.Runs[i].Tool.Driver.Name == "nada-security" {
+			securityRun = &log.Runs[i]
+		} else if len(log.Runs[i].Results) != 0 {
+			t.Errorf("run %q expected no results, got %d", log.Runs[i].Tool.Driver.Name, len(log.Runs[i].Results))
+		}
+	}
+	if securityRun == nil {
+		t.Fatal("expected a nada-security run")
+	}
+	if len(securityRun.Tool.Driver.Rules) == 0 {
+		t.Error("expected the security run's tool.driver.rules to be populated from the rule engine")
+	}
+	if len(securityRun.Results) != 1 {
+		t.Fatalf("expected 1 result in the security run, got %d", len(securityRun.Results))
+	}
+
+	result := securityRun.Results[0]
+	if result.RuleID != "sql_injection" {
+		t.Errorf("result.RuleID = %v, want sql_injection", result.RuleID)
+	}
+	if result.Level != "error" {
+		t.Errorf("result.Level = %v, want error", result.Level)
+	}
+	if result.Locations[0].PhysicalLocation.ArtifactLocation.URI != "main.go" {
+		t.Errorf("artifact uri = %v, want repo-relative main.go", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+	if result.PartialFingerprints["nadaFingerprint/v1"] == "" {
+		t.Error("expected a non-empty partial fingerprint")
+	}
+}
+
+func TestWriteSARIFReportUnknownCategoryGetsOwnRun(t *testing.T) {
+	report := testReport()
+	report.Issues[0].Category = "" // simulates a report saved before Category existed
+
+	var buf bytes.Buffer
+	if err := WriteSARIFReport(report, rules.NewEngine(), &buf); err != nil {
+		t.Fatalf("WriteSARIFReport() failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIFReport() produced invalid JSON: %v", err)
+	}
+
+	var generalRun *sarifRun
+	for i := range log.Runs {
+		if log.Runs[i].Tool.Driver.Name == "nada-general" {
+			generalRun = &log.Runs[i]
+		}
+	}
+	if generalRun == nil {
+		t.Fatal("expected a trailing nada-general run for uncategorized issues")
+	}
+	if len(generalRun.Results) != 1 {
+		t.Errorf("expected 1 result in the general run, got %d", len(generalRun.Results))
+	}
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJUnitReport(testReport(), &buf); err != nil {
+		t.Fatalf("WriteJUnitReport() failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "<testsuites>") {
+		t.Error("expected <testsuites> root element")
+	}
+	if !strings.Contains(out, `name="sql_injection"`) {
+		t.Error("expected a testsuite named after the rule")
+	}
+}
+
+func TestWriteCodeClimateReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCodeClimateReport(testReport(), &buf); err != nil {
+		t.Fatalf("WriteCodeClimateReport() failed: %v", err)
+	}
+
+	var issues []codeClimateIssue
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("WriteCodeClimateReport() produced invalid JSON: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].Severity != "blocker" {
+		t.Errorf("issues[0].Severity = %v, want blocker", issues[0].Severity)
+	}
+	if issues[0].Fingerprint == "" {
+		t.Error("expected a non-empty fingerprint")
+	}
+}
@@ -0,0 +1,79 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// JUnit XML schema subset wide enough for CI systems (Jenkins, GitLab, etc.)
+// to render Nada issues as test failures grouped by rule.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitReport emits report as a JUnit XML document, with one testsuite
+// per rule and one failing testcase per issue, so CI systems that only
+// understand "failed tests" can surface Nada findings alongside unit tests.
+func WriteJUnitReport(report *types.Report, w io.Writer) error {
+	byRule := make(map[string][]types.Issue)
+	var order []string
+	for _, issue := range report.Issues {
+		if _, ok := byRule[issue.Rule]; !ok {
+			order = append(order, issue.Rule)
+		}
+		byRule[issue.Rule] = append(byRule[issue.Rule], issue)
+	}
+
+	suites := junitTestSuites{}
+	for _, rule := range order {
+		issues := byRule[rule]
+		suite := junitTestSuite{
+			Name:     rule,
+			Tests:    len(issues),
+			Failures: len(issues),
+		}
+		for _, issue := range issues {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      fmt.Sprintf("%s:%d", issue.File, issue.Line),
+				ClassName: rule,
+				Failure: &junitFailure{
+					Message: issue.Message,
+					Type:    issue.Severity,
+					Text:    issue.Description,
+				},
+			})
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(suites)
+}
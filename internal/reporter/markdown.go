@@ -0,0 +1,64 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// WriteMarkdownReport emits report as a human-readable Markdown summary,
+// suitable for posting as a GitHub Actions job summary or PR comment.
+func WriteMarkdownReport(report *types.Report, w io.Writer) error {
+	fmt.Fprintf(w, "# Nada Code Quality Report\n\n")
+	fmt.Fprintf(w, "**Project:** %s\n", report.ProjectPath)
+	fmt.Fprintf(w, "**Analyzed:** %s\n", report.Timestamp.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "**Grade:** %s (%.1f/100)\n\n", report.Grade, report.Score)
+
+	fmt.Fprintf(w, "## Summary\n\n")
+	fmt.Fprintf(w, "| Metric | Value |\n")
+	fmt.Fprintf(w, "| --- | --- |\n")
+	fmt.Fprintf(w, "| Files Analyzed | %d |\n", report.FilesAnalyzed)
+	fmt.Fprintf(w, "| Lines of Code | %d |\n", report.Metrics.LinesOfCode)
+	fmt.Fprintf(w, "| Total Issues | %d |\n", len(report.Issues))
+	fmt.Fprintf(w, "| High | %d |\n", report.IssuesSummary[types.SeverityHigh])
+	fmt.Fprintf(w, "| Medium | %d |\n", report.IssuesSummary[types.SeverityMedium])
+	fmt.Fprintf(w, "| Low | %d |\n", report.IssuesSummary[types.SeverityLow])
+	fmt.Fprintf(w, "| Vulnerabilities | %d |\n\n", report.IssuesSummary[types.TypeVulnerability])
+
+	if len(report.Issues) == 0 {
+		fmt.Fprintf(w, "No issues found.\n")
+		return nil
+	}
+
+	issues := make([]types.Issue, len(report.Issues))
+	copy(issues, report.Issues)
+	severityOrder := map[string]int{types.SeverityHigh: 3, types.SeverityMedium: 2, types.SeverityLow: 1}
+	sort.Slice(issues, func(i, j int) bool {
+		return severityOrder[issues[i].Severity] > severityOrder[issues[j].Severity]
+	})
+
+	fmt.Fprintf(w, "## Issues\n\n")
+	fmt.Fprintf(w, "| Severity | Type | File | Line | Rule | Message |\n")
+	fmt.Fprintf(w, "| --- | --- | --- | --- | --- | --- |\n")
+	for _, issue := range issues {
+		fmt.Fprintf(w, "| %s | %s | %s | %d | %s | %s |\n",
+			issue.Severity, issue.Type, issue.File, issue.Line, issue.Rule, markdownEscape(issue.Message))
+	}
+
+	return nil
+}
+
+// markdownEscape neutralizes pipe characters in an issue message so it
+// can't break out of a Markdown table cell.
+func markdownEscape(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r == '|' {
+			out = append(out, '\\')
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
@@ -0,0 +1,108 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// statusInterval is how often a non-TTY progress stream prints a status
+// line, so CI logs get periodic updates without a line per file.
+const statusInterval = 2 * time.Second
+
+// IsTerminal reports whether f is attached to an interactive terminal. This
+// tree doesn't vendor golang.org/x/term, so it falls back to the portable
+// os.ModeCharDevice check most stdlib-only CLIs use.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Progress renders a live indicator of AnalyzeProject's file-by-file
+// progress, fed by types.ProgressEvents on a buffered channel so a slow
+// terminal write never blocks the analyzer's collector loop.
+type Progress struct {
+	events   chan types.ProgressEvent
+	done     chan struct{}
+	eventLog *EventLog
+}
+
+// NewProgress starts a renderer goroutine writing to w and returns a
+// Progress whose Events channel should be passed to
+// analyzer.CodeAnalyzer.SetProgress. When tty is true it redraws a single
+// line in place; otherwise it prints newline-terminated status lines at
+// most once per statusInterval, so piped/CI output stays readable.
+func NewProgress(w io.Writer, tty bool) *Progress {
+	return newProgress(w, tty, nil)
+}
+
+// NewProgressWithEventLog is like NewProgress, but additionally forwards
+// every event to eventLog's FileAnalyzed (see --log-events), from the same
+// consumer goroutine that renders to w - so w can be io.Discard when a
+// caller wants the NDJSON log without a visible progress bar.
+func NewProgressWithEventLog(w io.Writer, tty bool, eventLog *EventLog) *Progress {
+	return newProgress(w, tty, eventLog)
+}
+
+func newProgress(w io.Writer, tty bool, eventLog *EventLog) *Progress {
+	p := &Progress{
+		events:   make(chan types.ProgressEvent, 64),
+		done:     make(chan struct{}),
+		eventLog: eventLog,
+	}
+	go p.run(w, tty)
+	return p
+}
+
+// Events returns the send side of the channel analyzer.CodeAnalyzer.SetProgress expects.
+func (p *Progress) Events() chan<- types.ProgressEvent {
+	return p.events
+}
+
+// Close signals that no further events will be sent and blocks until the
+// renderer goroutine has drained the channel and printed its final line.
+func (p *Progress) Close() {
+	close(p.events)
+	<-p.done
+}
+
+func (p *Progress) run(w io.Writer, tty bool) {
+	defer close(p.done)
+
+	severity := map[string]int{}
+	var lastPrinted time.Time
+
+	for evt := range p.events {
+		for _, issue := range evt.Issues {
+			severity[issue.Severity]++
+		}
+
+		if p.eventLog != nil {
+			p.eventLog.FileAnalyzed(evt)
+		}
+
+		if tty {
+			fmt.Fprintf(w, "\r⏳ %d/%d  %s  🔴%d 🟡%d 🟢%d",
+				evt.FilesDone, evt.FilesTotal, evt.File,
+				severity[types.SeverityHigh], severity[types.SeverityMedium], severity[types.SeverityLow])
+			continue
+		}
+
+		if evt.FilesDone == evt.FilesTotal || time.Since(lastPrinted) >= statusInterval {
+			fmt.Fprintf(w, "progress: %d/%d files (🔴%d 🟡%d 🟢%d)\n",
+				evt.FilesDone, evt.FilesTotal,
+				severity[types.SeverityHigh], severity[types.SeverityMedium], severity[types.SeverityLow])
+			lastPrinted = time.Now()
+		}
+	}
+
+	if tty {
+		fmt.Fprintln(w)
+	}
+}
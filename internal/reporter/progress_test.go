@@ -0,0 +1,46 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func TestProgressTTYRedrawsOneLine(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgress(&buf, true)
+
+	p.Events() <- types.ProgressEvent{File: "a.go", FilesDone: 1, FilesTotal: 2}
+	p.Events() <- types.ProgressEvent{
+		File: "b.go", FilesDone: 2, FilesTotal: 2,
+		Issues: []types.Issue{{Severity: types.SeverityHigh}},
+	}
+	p.Close()
+
+	out := buf.String()
+	if strings.Count(out, "\r") != 2 {
+		t.Errorf("Progress(tty) output = %q, want one \\r-prefixed line per event", out)
+	}
+	if !strings.Contains(out, "2/2") || !strings.Contains(out, "🔴1") {
+		t.Errorf("Progress(tty) output = %q, want final counts 2/2 and 🔴1", out)
+	}
+}
+
+func TestProgressNonTTYPrintsFinalLine(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgress(&buf, false)
+
+	p.Events() <- types.ProgressEvent{File: "a.go", FilesDone: 1, FilesTotal: 2}
+	p.Events() <- types.ProgressEvent{File: "b.go", FilesDone: 2, FilesTotal: 2}
+	p.Close()
+
+	out := buf.String()
+	if strings.Contains(out, "\r") {
+		t.Errorf("Progress(non-tty) output = %q, want no carriage returns", out)
+	}
+	if !strings.Contains(out, "progress: 2/2 files") {
+		t.Errorf("Progress(non-tty) output = %q, want a final 2/2 status line", out)
+	}
+}
@@ -0,0 +1,306 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/chaksack/nada/internal/rules"
+	"github.com/chaksack/nada/internal/types"
+)
+
+// SARIF schema subset (2.1.0) required to describe a Nada report.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/ for the full spec.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                       `json:"id"`
+	Name                 string                       `json:"name"`
+	ShortDescription     sarifMessage                 `json:"shortDescription"`
+	FullDescription      sarifMessage                 `json:"fullDescription,omitempty"`
+	HelpURI              string                       `json:"helpUri,omitempty"`
+	DefaultConfiguration *sarifReportingConfiguration `json:"defaultConfiguration,omitempty"`
+}
+
+// sarifReportingConfiguration carries a rule's default severity, so
+// consumers that only read tool.driver.rules (without any results yet) can
+// still show a level for it.
+type sarifReportingConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          *sarifProperties  `json:"properties,omitempty"`
+}
+
+// sarifProperties carries vendor-specific data SARIF's core schema has no
+// dedicated field for - here, the issue's estimated remediation effort, so a
+// consumer aggregating uploaded results (e.g. GitHub code scanning) can still
+// surface it.
+type sarifProperties struct {
+	Effort int `json:"effort,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// WriteSARIFReport emits report as a SARIF 2.1.0 log to w, with one run per
+// rule engine (structure, security, complexity, ...) so consumers can
+// attribute findings to the specific check that produced them. When engine
+// is non-nil, each run's tool.driver.rules is populated from that engine's
+// registered rule catalog and a run is emitted for every registered rule
+// even if it found nothing this time; issues whose Category doesn't match
+// any registered rule (e.g. a report analyzed without its original engine)
+// get their own trailing run synthesized from the issues themselves.
+func WriteSARIFReport(report *types.Report, engine *rules.Engine, w io.Writer) error {
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    sarifRuns(report, engine),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifRuns groups report.Issues by Category (the ID of the rule that
+// produced them) and builds one run per rule engine, in registration order.
+func sarifRuns(report *types.Report, engine *rules.Engine) []sarifRun {
+	issuesByCategory := make(map[string][]types.Issue)
+	var categoryOrder []string
+	for _, issue := range report.Issues {
+		category := issue.Category
+		if category == "" {
+			category = "general"
+		}
+		if _, seen := issuesByCategory[category]; !seen {
+			categoryOrder = append(categoryOrder, category)
+		}
+		issuesByCategory[category] = append(issuesByCategory[category], issue)
+	}
+
+	var runs []sarifRun
+	emitted := make(map[string]bool)
+
+	if engine != nil {
+		for _, rule := range engine.GetRules() {
+			runs = append(runs, sarifRun{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "nada-" + rule.ID(),
+						InformationURI: "https://github.com/chaksack/nada",
+						Rules: []sarifRule{{
+							ID:                   rule.ID(),
+							Name:                 rule.Name(),
+							ShortDescription:     sarifMessage{Text: rule.Description()},
+							HelpURI:              sarifHelpURI(rule.ID()),
+							DefaultConfiguration: &sarifReportingConfiguration{Level: sarifDefaultLevel(issuesByCategory[rule.ID()])},
+						}},
+					},
+				},
+				Results: sarifResultsFromIssues(report.ProjectPath, issuesByCategory[rule.ID()]),
+			})
+			emitted[rule.ID()] = true
+		}
+	}
+
+	for _, category := range categoryOrder {
+		if emitted[category] {
+			continue
+		}
+		runs = append(runs, sarifRun{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:  "nada-" + category,
+					Rules: sarifRuleCatalogFromIssues(issuesByCategory[category]),
+				},
+			},
+			Results: sarifResultsFromIssues(report.ProjectPath, issuesByCategory[category]),
+		})
+	}
+
+	if len(runs) == 0 {
+		runs = append(runs, sarifRun{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "nada"}},
+			Results: []sarifResult{},
+		})
+	}
+
+	return runs
+}
+
+// sarifRuleCatalogFromIssues synthesizes a tool.driver.rules[] entry per
+// distinct rule id, used when a run's category has no corresponding
+// registered rule to describe it.
+func sarifRuleCatalogFromIssues(issues []types.Issue) []sarifRule {
+	seen := make(map[string]bool)
+	var order []string
+	byRule := make(map[string][]types.Issue)
+	for _, issue := range issues {
+		if !seen[issue.Rule] {
+			seen[issue.Rule] = true
+			order = append(order, issue.Rule)
+		}
+		byRule[issue.Rule] = append(byRule[issue.Rule], issue)
+	}
+
+	catalog := make([]sarifRule, 0, len(order))
+	for _, id := range order {
+		catalog = append(catalog, sarifRule{
+			ID:                   id,
+			Name:                 id,
+			ShortDescription:     sarifMessage{Text: byRule[id][0].Message},
+			HelpURI:              sarifHelpURI(id),
+			DefaultConfiguration: &sarifReportingConfiguration{Level: sarifDefaultLevel(byRule[id])},
+		})
+	}
+	return catalog
+}
+
+// sarifHelpURI links a rule id back to the Nada repo, since individual rules
+// don't have their own hosted docs pages.
+func sarifHelpURI(ruleID string) string {
+	return "https://github.com/chaksack/nada#" + ruleID
+}
+
+// sarifDefaultLevel picks a rule's defaultConfiguration.level from the
+// highest-severity issue it produced this run, so tool.driver.rules carries
+// a sensible level even before a consumer looks at any individual result.
+// Rules that found nothing this run default to "warning".
+func sarifDefaultLevel(issues []types.Issue) string {
+	rank := map[string]int{"note": 0, "warning": 1, "error": 2}
+	best := "warning"
+	found := false
+	for _, issue := range issues {
+		level := sarifLevel(issue.Severity)
+		if !found || rank[level] > rank[best] {
+			best = level
+			found = true
+		}
+	}
+	return best
+}
+
+// sarifResultsFromIssues maps a run's issues to SARIF results.
+func sarifResultsFromIssues(projectPath string, issues []types.Issue) []sarifResult {
+	results := make([]sarifResult, 0, len(issues))
+	for _, issue := range issues {
+		results = append(results, sarifResultFromIssue(projectPath, issue))
+	}
+	return results
+}
+
+// sarifResultFromIssue maps a types.Issue to a SARIF result.
+func sarifResultFromIssue(projectPath string, issue types.Issue) sarifResult {
+	uri := issue.File
+	if rel, err := filepath.Rel(projectPath, issue.File); err == nil {
+		uri = filepath.ToSlash(rel)
+	}
+
+	result := sarifResult{
+		RuleID:  issue.Rule,
+		Level:   sarifLevel(issue.Severity),
+		Message: sarifMessage{Text: issue.Message},
+		Locations: []sarifLocation{
+			{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: uri},
+					Region: sarifRegion{
+						StartLine:   issue.Line,
+						StartColumn: issue.Column,
+						EndLine:     issue.Line,
+						EndColumn:   issue.Column,
+					},
+				},
+			},
+		},
+		PartialFingerprints: map[string]string{
+			"nadaFingerprint/v1": partialFingerprint(issue),
+		},
+	}
+
+	if issue.Impact.EffortMinutes > 0 {
+		result.Properties = &sarifProperties{Effort: issue.Impact.EffortMinutes}
+	}
+
+	return result
+}
+
+// sarifLevel maps a types.Severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case types.SeverityHigh:
+		return "error"
+	case types.SeverityMedium:
+		return "warning"
+	case types.SeverityLow:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+// partialFingerprint prefers issue.Fingerprint - CodeAnalyzer.computeFingerprint's
+// hash of (rule, repo-relative file, surrounding source lines), the same
+// identity baseline diffing uses - so a SARIF consumer dedupes a finding
+// across commits using the exact same notion of "same issue" nada's own
+// --baseline does. Falls back to a rule+file+message hash for issues that
+// bypassed that fingerprinting (e.g. external linter findings merged in by
+// runExternalLinters after the fact).
+func partialFingerprint(issue types.Issue) string {
+	if issue.Fingerprint != "" {
+		return issue.Fingerprint
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s", issue.Rule, issue.File, issue.Message)))
+	return hex.EncodeToString(sum[:])
+}
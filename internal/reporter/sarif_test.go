@@ -0,0 +1,146 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/chaksack/nada/internal/rules"
+)
+
+func TestWriteSARIFReportRuleHelpURIAndDefaultLevel(t *testing.T) {
+	engine := rules.NewEngine()
+
+	report := testReport()
+	report.Issues[0].Category = "security"
+
+	var buf bytes.Buffer
+	if err := WriteSARIFReport(report, engine, &buf); err != nil {
+		t.Fatalf("WriteSARIFReport() failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIFReport() produced invalid JSON: %v", err)
+	}
+
+	var securityRule *sarifRule
+	for i := range log.Runs {
+		if log.Runs[i].Tool.Driver.Name != "nada-security" {
+			continue
+		}
+		for j := range log.Runs[i].Tool.Driver.Rules {
+			if log.Runs[i].Tool.Driver.Rules[j].ID == "security" {
+				securityRule = &log.Runs[i].Tool.Driver.Rules[j]
+			}
+		}
+	}
+	if securityRule == nil {
+		t.Fatal("expected a security rule entry in the security run's tool.driver.rules")
+	}
+	if securityRule.HelpURI == "" {
+		t.Error("expected a non-empty helpUri")
+	}
+	if securityRule.DefaultConfiguration == nil || securityRule.DefaultConfiguration.Level != "error" {
+		t.Errorf("expected defaultConfiguration.level = error (from the one high-severity result), got %+v", securityRule.DefaultConfiguration)
+	}
+}
+
+// TestWriteSARIFReportReusesIssueFingerprint checks that partialFingerprints
+// reuses types.Issue.Fingerprint - CodeAnalyzer.computeFingerprint's
+// surrounding-line-snippet hash, the same identity --baseline diffing uses -
+// when the issue already has one, rather than computing its own independent
+// hash.
+func TestWriteSARIFReportReusesIssueFingerprint(t *testing.T) {
+	report := testReport()
+	report.Issues[0].Category = "security"
+	report.Issues[0].Fingerprint = "precomputed-line-snippet-hash"
+
+	var buf bytes.Buffer
+	if err := WriteSARIFReport(report, rules.NewEngine(), &buf); err != nil {
+		t.Fatalf("WriteSARIFReport() failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIFReport() produced invalid JSON: %v", err)
+	}
+
+	for _, run := range log.Runs {
+		for _, result := range run.Results {
+			if result.RuleID != "sql_injection" {
+				continue
+			}
+			if got := result.PartialFingerprints["nadaFingerprint/v1"]; got != "precomputed-line-snippet-hash" {
+				t.Errorf("partialFingerprints[nadaFingerprint/v1] = %q, want the reused issue.Fingerprint", got)
+			}
+		}
+	}
+}
+
+// TestWriteSARIFReportSchemaConformance is a lightweight stand-in for
+// validating against the real SARIF 2.1.0 JSON schema (this tree has no
+// schema validator vendored): it round-trips the log through JSON and checks
+// every field the spec marks required is present and non-empty.
+func TestWriteSARIFReportSchemaConformance(t *testing.T) {
+	report := testReport()
+	report.Issues[0].Category = "security"
+
+	var buf bytes.Buffer
+	if err := WriteSARIFReport(report, rules.NewEngine(), &buf); err != nil {
+		t.Fatalf("WriteSARIFReport() failed: %v", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatalf("WriteSARIFReport() produced invalid JSON: %v", err)
+	}
+
+	if raw["$schema"] == "" || raw["$schema"] == nil {
+		t.Error("missing required top-level $schema")
+	}
+	if raw["version"] != "2.1.0" {
+		t.Errorf("version = %v, want 2.1.0", raw["version"])
+	}
+
+	runs, ok := raw["runs"].([]interface{})
+	if !ok || len(runs) == 0 {
+		t.Fatal("missing required top-level runs[]")
+	}
+
+	for _, r := range runs {
+		run := r.(map[string]interface{})
+		driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+		if driver["name"] == "" || driver["name"] == nil {
+			t.Error("run.tool.driver.name is required and must be non-empty")
+		}
+
+		results, _ := run["results"].([]interface{})
+		for _, res := range results {
+			result := res.(map[string]interface{})
+			if result["ruleId"] == "" || result["ruleId"] == nil {
+				t.Error("result.ruleId is required")
+			}
+			if result["level"] == "" || result["level"] == nil {
+				t.Error("result.level is required")
+			}
+			message, ok := result["message"].(map[string]interface{})
+			if !ok || message["text"] == "" {
+				t.Error("result.message.text is required")
+			}
+			locations, ok := result["locations"].([]interface{})
+			if !ok || len(locations) == 0 {
+				t.Fatal("result.locations is required and must be non-empty")
+			}
+			physical := locations[0].(map[string]interface{})["physicalLocation"].(map[string]interface{})
+			artifact := physical["artifactLocation"].(map[string]interface{})
+			if artifact["uri"] == "" || artifact["uri"] == nil {
+				t.Error("result.locations[0].physicalLocation.artifactLocation.uri is required")
+			}
+			region := physical["region"].(map[string]interface{})
+			if region["startLine"] == nil {
+				t.Error("result.locations[0].physicalLocation.region.startLine is required")
+			}
+		}
+	}
+}
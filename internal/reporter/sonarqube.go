@@ -0,0 +1,96 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// sonarQubeReport matches SonarQube/SonarCloud's generic issue import format
+// (https://docs.sonarsource.com/sonarqube/latest/analyzing-source-code/importing-external-issues/generic-issue-import-format/),
+// which lets nada findings show up as SonarQube issues without a dedicated
+// plugin.
+type sonarQubeReport struct {
+	Issues []sonarQubeIssue `json:"issues"`
+}
+
+type sonarQubeIssue struct {
+	EngineID        string            `json:"engineId"`
+	RuleID          string            `json:"ruleId"`
+	Severity        string            `json:"severity"`
+	Type            string            `json:"type"`
+	PrimaryLocation sonarQubeLocation `json:"primaryLocation"`
+	EffortMinutes   int               `json:"effortMinutes,omitempty"`
+}
+
+type sonarQubeLocation struct {
+	Message   string         `json:"message"`
+	FilePath  string         `json:"filePath"`
+	TextRange sonarQubeRange `json:"textRange"`
+}
+
+type sonarQubeRange struct {
+	StartLine int `json:"startLine"`
+}
+
+// WriteSonarQubeReport emits report as SonarQube's generic issue import
+// JSON, one issue per types.Issue.
+func WriteSonarQubeReport(report *types.Report, w io.Writer) error {
+	out := sonarQubeReport{Issues: make([]sonarQubeIssue, 0, len(report.Issues))}
+	for _, issue := range report.Issues {
+		out.Issues = append(out.Issues, sonarQubeIssue{
+			EngineID: "nada",
+			RuleID:   issue.Rule,
+			Severity: sonarQubeSeverity(issue.Severity),
+			Type:     sonarQubeType(issue.Type),
+			PrimaryLocation: sonarQubeLocation{
+				Message:  issue.Message,
+				FilePath: issue.File,
+				TextRange: sonarQubeRange{
+					StartLine: sonarQubeLine(issue.Line),
+				},
+			},
+			EffortMinutes: issue.Impact.EffortMinutes,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// sonarQubeSeverity maps a types.Severity to a SonarQube issue severity.
+func sonarQubeSeverity(severity string) string {
+	switch severity {
+	case types.SeverityHigh:
+		return "BLOCKER"
+	case types.SeverityMedium:
+		return "MAJOR"
+	case types.SeverityLow:
+		return "MINOR"
+	default:
+		return "INFO"
+	}
+}
+
+// sonarQubeType maps a types.Issue type to a SonarQube issue type.
+func sonarQubeType(issueType string) string {
+	switch issueType {
+	case types.TypeVulnerability:
+		return "VULNERABILITY"
+	case types.TypeBug, types.TypeError:
+		return "BUG"
+	default:
+		return "CODE_SMELL"
+	}
+}
+
+// sonarQubeLine clamps an issue's line to 1, since the generic issue import
+// format rejects a textRange.startLine of 0.
+func sonarQubeLine(line int) int {
+	if line < 1 {
+		return 1
+	}
+	return line
+}
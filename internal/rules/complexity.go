@@ -9,12 +9,72 @@ import (
 	"github.com/chaksack/nada/internal/types"
 )
 
+// defaultComplexityThreshold is the cyclomatic complexity above which
+// checkFunctionComplexity reports an issue, absent a config override.
+const defaultComplexityThreshold = 10
+
+// defaultFunctionLinesMax and defaultNestingMax are checkFunctionSize's and
+// checkDeepNesting's thresholds absent a config override, same role as
+// defaultComplexityThreshold plays for checkFunctionComplexity.
+const (
+	defaultFunctionLinesMax = 50
+	defaultNestingMax       = 4
+)
+
+// Cognitive complexity thresholds, per Sonar's "Cognitive Complexity" paper:
+// nested and branching control flow reads harder than cyclomatic complexity
+// alone implies, so these run higher than the cyclomatic thresholds above
+// and aren't tied to ComplexityRule.Threshold.
+const (
+	cognitiveComplexityMediumThreshold = 15
+	cognitiveComplexityHighThreshold   = 25
+)
+
+// ComplexityConfig holds the ".nada.yaml"/".nada.json" "rules.complexity"
+// thresholds (see config.RulesConfig). A zero field means "use this rule's
+// built-in default" rather than "flag everything", the same convention
+// ComplexityRule.Threshold already follows.
+type ComplexityConfig struct {
+	CyclomaticMax    int
+	FunctionLinesMax int
+	NestingMax       int
+}
+
 // ComplexityRule checks for cyclomatic complexity and code structure issues
-type ComplexityRule struct{}
+type ComplexityRule struct {
+	// Threshold is the cyclomatic complexity above which a function is
+	// flagged; complexity more than 1.5x this is escalated to high severity.
+	Threshold int
+	// FunctionLinesMax and NestingMax are checkFunctionSize's and
+	// checkDeepNesting's thresholds; <= 0 falls back to
+	// defaultFunctionLinesMax/defaultNestingMax, the same as Threshold.
+	FunctionLinesMax int
+	NestingMax       int
+}
 
-// NewComplexityRule creates a new complexity rule
+// NewComplexityRule creates a new complexity rule using the default
+// complexity threshold.
 func NewComplexityRule() *ComplexityRule {
-	return &ComplexityRule{}
+	return &ComplexityRule{Threshold: defaultComplexityThreshold}
+}
+
+// NewComplexityRuleWithThreshold creates a complexity rule that flags
+// functions whose cyclomatic complexity exceeds threshold, for config-driven
+// tuning (the ".nada.yaml" flat "thresholds.complexity" key; see
+// NewComplexityRuleFromConfig for the structured "rules.complexity" section).
+func NewComplexityRuleWithThreshold(threshold int) *ComplexityRule {
+	return &ComplexityRule{Threshold: threshold}
+}
+
+// NewComplexityRuleFromConfig creates a complexity rule tuned by cfg's
+// cyclomatic/function-lines/nesting thresholds, for the ".nada.yaml"
+// "rules.complexity" section (see config.RulesConfig).
+func NewComplexityRuleFromConfig(cfg ComplexityConfig) *ComplexityRule {
+	return &ComplexityRule{
+		Threshold:        cfg.CyclomaticMax,
+		FunctionLinesMax: cfg.FunctionLinesMax,
+		NestingMax:       cfg.NestingMax,
+	}
 }
 
 // ID returns the rule identifier
@@ -45,6 +105,7 @@ func (r *ComplexityRule) Check(file string, node ast.Node, content string, fset
 		case *ast.FuncDecl:
 			issues = append(issues, r.checkFunctionComplexity(file, x, fset)...)
 			issues = append(issues, r.checkFunctionSize(file, x, fset)...)
+			issues = append(issues, r.checkCognitiveComplexity(file, x, fset)...)
 		case *ast.IfStmt:
 			issues = append(issues, r.checkDeepNesting(file, x, content, fset)...)
 		}
@@ -65,10 +126,14 @@ func (r *ComplexityRule) checkFunctionComplexity(file string, fn *ast.FuncDecl,
 
 	complexity := r.calculateComplexity(fn)
 	pos := fset.Position(fn.Pos())
+	threshold := r.Threshold
+	if threshold <= 0 {
+		threshold = defaultComplexityThreshold
+	}
 
-	if complexity > 10 {
+	if complexity > threshold {
 		severity := types.SeverityMedium
-		if complexity > 15 {
+		if complexity > threshold+threshold/2 {
 			severity = types.SeverityHigh
 		}
 
@@ -80,7 +145,7 @@ func (r *ComplexityRule) checkFunctionComplexity(file string, fn *ast.FuncDecl,
 			Column:      pos.Column,
 			Rule:        "high_complexity",
 			Message:     "High cyclomatic complexity",
-			Description: fmt.Sprintf("Function '%s' has complexity %d (threshold: 10)", fn.Name.Name, complexity),
+			Description: fmt.Sprintf("Function '%s' has complexity %d (threshold: %d)", fn.Name.Name, complexity, threshold),
 			Impact:      types.IssueImpact{EffortMinutes: complexity * 2},
 		})
 	}
@@ -106,6 +171,266 @@ func (r *ComplexityRule) calculateComplexity(fn *ast.FuncDecl) int {
 	return complexity
 }
 
+// checkCognitiveComplexity flags functions whose cognitive complexity (see
+// calculateCognitiveComplexity) exceeds cognitiveComplexityMediumThreshold,
+// as a separate issue from checkFunctionComplexity's cyclomatic count:
+// a function can be cyclomatically simple (a long flat switch) yet
+// cognitively easy, or vice versa (deeply nested ifs), so the two checks
+// deliberately don't share a threshold or an issue.
+func (r *ComplexityRule) checkCognitiveComplexity(file string, fn *ast.FuncDecl, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	if fn.Body == nil || fn.Name == nil {
+		return issues
+	}
+
+	complexity := CognitiveComplexity(fn)
+	if complexity <= cognitiveComplexityMediumThreshold {
+		return issues
+	}
+
+	severity := types.SeverityMedium
+	if complexity > cognitiveComplexityHighThreshold {
+		severity = types.SeverityHigh
+	}
+
+	pos := fset.Position(fn.Pos())
+	issues = append(issues, types.Issue{
+		Type:        types.TypeCodeSmell,
+		Severity:    severity,
+		File:        file,
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Rule:        "high_cognitive_complexity",
+		Message:     "High cognitive complexity",
+		Description: fmt.Sprintf("Function '%s' has cognitive complexity %d (threshold: %d)", fn.Name.Name, complexity, cognitiveComplexityMediumThreshold),
+		Impact:      types.IssueImpact{EffortMinutes: complexity * 2},
+	})
+
+	return issues
+}
+
+// CognitiveComplexity scores fn per Sonar's Cognitive Complexity metric:
+// each control-flow structure costs 1 plus the current nesting depth (so
+// the same "if" costs more the deeper it's nested), while flat, non-nesting
+// increments (else branches, case clauses, labeled break/continue,
+// defer/go, and a change of operator in a boolean chain) always cost a
+// flat 1 regardless of nesting. A recursive call to fn's own name also
+// costs 1, since it forces the reader to hold the whole function in mind
+// again. Exported so internal/analyzer can fold it into Metrics.
+// CognitiveComplexity and FunctionMetrics.CognitiveComplexity without
+// duplicating this walk.
+func CognitiveComplexity(fn *ast.FuncDecl) int {
+	if fn.Body == nil {
+		return 0
+	}
+
+	w := &cognitiveWalker{funcName: fn.Name.Name}
+	w.walk(fn.Body, 0)
+	return w.score
+}
+
+type cognitiveWalker struct {
+	score    int
+	funcName string
+}
+
+// walk scores stmt/expr nodes under n at nesting depth, incrementing depth
+// for every nesting construct it recurses into.
+func (w *cognitiveWalker) walk(n ast.Node, depth int) {
+	if n == nil {
+		return
+	}
+
+	switch x := n.(type) {
+	case *ast.IfStmt:
+		w.score += 1 + depth
+		w.walk(x.Init, depth)
+		w.walk(x.Cond, depth)
+		w.walk(x.Body, depth+1)
+		if x.Else != nil {
+			if _, isElseIf := x.Else.(*ast.IfStmt); isElseIf {
+				// "else if" is a flat continuation of the same chain, not
+				// an additional nesting level or its own +depth increment.
+				w.score++
+				w.walkElseIf(x.Else.(*ast.IfStmt), depth)
+			} else {
+				w.score++
+				w.walk(x.Else, depth+1)
+			}
+		}
+
+	case *ast.ForStmt:
+		w.score += 1 + depth
+		w.walk(x.Init, depth)
+		w.walk(x.Cond, depth)
+		w.walk(x.Post, depth)
+		w.walk(x.Body, depth+1)
+
+	case *ast.RangeStmt:
+		w.score += 1 + depth
+		w.walk(x.Body, depth+1)
+
+	case *ast.SwitchStmt:
+		w.score += 1 + depth
+		w.walk(x.Init, depth)
+		w.walk(x.Tag, depth)
+		w.walkBody(x.Body, depth+1)
+
+	case *ast.TypeSwitchStmt:
+		w.score += 1 + depth
+		w.walk(x.Init, depth)
+		w.walkBody(x.Body, depth+1)
+
+	case *ast.SelectStmt:
+		w.score += 1 + depth
+		w.walkBody(x.Body, depth+1)
+
+	case *ast.CaseClause:
+		w.score++
+		for _, e := range x.List {
+			w.walk(e, depth)
+		}
+		for _, s := range x.Body {
+			w.walk(s, depth)
+		}
+
+	case *ast.CommClause:
+		w.score++
+		w.walk(x.Comm, depth)
+		for _, s := range x.Body {
+			w.walk(s, depth)
+		}
+
+	case *ast.BranchStmt:
+		if x.Label != nil {
+			w.score++
+		}
+
+	case *ast.DeferStmt:
+		w.score++
+		w.walk(x.Call, depth)
+
+	case *ast.GoStmt:
+		w.score++
+		w.walk(x.Call, depth)
+
+	case *ast.FuncLit:
+		w.walk(x.Body, depth+1)
+
+	case *ast.BinaryExpr:
+		if isLogicalOp(x.Op) {
+			w.scoreBoolChain(x)
+			return
+		}
+		w.walk(x.X, depth)
+		w.walk(x.Y, depth)
+
+	case *ast.CallExpr:
+		if ident, ok := x.Fun.(*ast.Ident); ok && ident.Name == w.funcName {
+			w.score++
+		}
+		w.walk(x.Fun, depth)
+		for _, arg := range x.Args {
+			w.walk(arg, depth)
+		}
+
+	case *ast.BlockStmt:
+		for _, s := range x.List {
+			w.walk(s, depth)
+		}
+
+	case *ast.ExprStmt:
+		w.walk(x.X, depth)
+
+	case *ast.AssignStmt:
+		for _, e := range x.Rhs {
+			w.walk(e, depth)
+		}
+
+	case *ast.ReturnStmt:
+		for _, e := range x.Results {
+			w.walk(e, depth)
+		}
+
+	case *ast.LabeledStmt:
+		w.walk(x.Stmt, depth)
+
+	case *ast.ParenExpr:
+		w.walk(x.X, depth)
+
+	case *ast.UnaryExpr:
+		w.walk(x.X, depth)
+	}
+}
+
+// walkElseIf scores an "else if" chain's own condition/body/nested-else at
+// depth (not depth+1), since the chain reads as one flat sequence of
+// conditions rather than nested blocks.
+func (w *cognitiveWalker) walkElseIf(stmt *ast.IfStmt, depth int) {
+	w.walk(stmt.Init, depth)
+	w.walk(stmt.Cond, depth)
+	w.walk(stmt.Body, depth+1)
+	if stmt.Else != nil {
+		if elseIf, ok := stmt.Else.(*ast.IfStmt); ok {
+			w.score++
+			w.walkElseIf(elseIf, depth)
+		} else {
+			w.score++
+			w.walk(stmt.Else, depth+1)
+		}
+	}
+}
+
+func (w *cognitiveWalker) walkBody(body *ast.BlockStmt, depth int) {
+	if body == nil {
+		return
+	}
+	for _, s := range body.List {
+		w.walk(s, depth)
+	}
+}
+
+// scoreBoolChain flattens a chain of the same-precedence &&/|| BinaryExprs
+// and adds 1 per change of operator in the sequence, per the Cognitive
+// Complexity spec's treatment of mixed boolean operators (e.g. "a && b || c"
+// scores 2: one for the run of &&, one for the switch to ||). A uniform
+// chain of one operator, however long, scores 1.
+func (w *cognitiveWalker) scoreBoolChain(expr *ast.BinaryExpr) {
+	ops := flattenLogicalOps(expr)
+	if len(ops) == 0 {
+		return
+	}
+
+	w.score++
+	for i := 1; i < len(ops); i++ {
+		if ops[i] != ops[i-1] {
+			w.score++
+		}
+	}
+}
+
+// flattenLogicalOps walks a left-leaning tree of &&/|| BinaryExprs and
+// returns the sequence of operators encountered in source order, descending
+// into each leaf operand to also score any nested boolean chains or other
+// control flow it contains.
+func flattenLogicalOps(expr ast.Expr) []token.Token {
+	binary, ok := expr.(*ast.BinaryExpr)
+	if !ok || !isLogicalOp(binary.Op) {
+		return nil
+	}
+
+	var ops []token.Token
+	ops = append(ops, flattenLogicalOps(binary.X)...)
+	ops = append(ops, binary.Op)
+	ops = append(ops, flattenLogicalOps(binary.Y)...)
+	return ops
+}
+
+func isLogicalOp(op token.Token) bool {
+	return op == token.LAND || op == token.LOR
+}
+
 // checkFunctionSize checks if functions are too large
 func (r *ComplexityRule) checkFunctionSize(file string, fn *ast.FuncDecl, fset *token.FileSet) []types.Issue {
 	var issues []types.Issue
@@ -118,9 +443,14 @@ func (r *ComplexityRule) checkFunctionSize(file string, fn *ast.FuncDecl, fset *
 	end := fset.Position(fn.Body.Rbrace)
 	lines := end.Line - start.Line
 
-	if lines > 50 {
+	max := r.FunctionLinesMax
+	if max <= 0 {
+		max = defaultFunctionLinesMax
+	}
+
+	if lines > max {
 		severity := types.SeverityMedium
-		if lines > 100 {
+		if lines > max*2 {
 			severity = types.SeverityHigh
 		}
 
@@ -133,7 +463,7 @@ func (r *ComplexityRule) checkFunctionSize(file string, fn *ast.FuncDecl, fset *
 			Column:      pos.Column,
 			Rule:        "large_function",
 			Message:     "Function too large",
-			Description: fmt.Sprintf("Function '%s' has %d lines (threshold: 50)", fn.Name.Name, lines),
+			Description: fmt.Sprintf("Function '%s' has %d lines (threshold: %d)", fn.Name.Name, lines, max),
 			Impact:      types.IssueImpact{EffortMinutes: lines / 10},
 		})
 	}
@@ -152,7 +482,12 @@ func (r *ComplexityRule) checkDeepNesting(file string, stmt *ast.IfStmt, content
 		line := lines[pos.Line-1]
 		indentLevel := r.calculateIndentLevel(line)
 
-		if indentLevel > 4 {
+		max := r.NestingMax
+		if max <= 0 {
+			max = defaultNestingMax
+		}
+
+		if indentLevel > max {
 			issues = append(issues, types.Issue{
 				Type:        types.TypeCodeSmell,
 				Severity:    types.SeverityMedium,
@@ -161,7 +496,7 @@ func (r *ComplexityRule) checkDeepNesting(file string, stmt *ast.IfStmt, content
 				Column:      pos.Column,
 				Rule:        "deep_nesting",
 				Message:     "Deep nesting detected",
-				Description: fmt.Sprintf("Code is nested %d levels deep (threshold: 4)", indentLevel),
+				Description: fmt.Sprintf("Code is nested %d levels deep (threshold: %d)", indentLevel, max),
 				Impact:      types.IssueImpact{EffortMinutes: 5},
 			})
 		}
@@ -0,0 +1,390 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// IneffAssignRule flags assignments whose value is never read before being
+// overwritten or the variable goes out of scope. Unlike the AnalyzerAdapter
+// heuristic registered as "ineffassign" (same-block, single-pass tracking),
+// this is a real reaching-definitions style check: it builds a control
+// flow graph per function and runs backward liveness analysis to a fixed
+// point, so it also catches dead stores across if/for/switch branches
+// rather than only within one straight-line block.
+type IneffAssignRule struct{}
+
+// NewIneffAssignRule builds the CFG-based dead-store rule.
+func NewIneffAssignRule() *IneffAssignRule {
+	return &IneffAssignRule{}
+}
+
+func (r *IneffAssignRule) ID() string   { return "ineffectual_assignment" }
+func (r *IneffAssignRule) Name() string { return "Dead Store Analysis" }
+func (r *IneffAssignRule) Description() string {
+	return "Flags assignments whose value is never read before being overwritten, using per-function liveness analysis"
+}
+
+// Check implements Rule.
+func (r *IneffAssignRule) Check(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			if fn.Body != nil {
+				issues = append(issues, analyzeFuncLiveness(file, fn, fn.Body, fset)...)
+			}
+		case *ast.FuncLit:
+			issues = append(issues, analyzeFuncLiveness(file, fn, fn.Body, fset)...)
+		}
+		return true
+	})
+
+	return issues
+}
+
+// analyzeFuncLiveness runs the dead-store check over a single function's
+// body. funcNode is the *ast.FuncDecl or *ast.FuncLit the body belongs to,
+// used to tell this function's own locals (and parameters) apart from
+// package-level variables and outer-scope captures by position
+// containment.
+func analyzeFuncLiveness(file string, funcNode, body ast.Node, fset *token.FileSet) []types.Issue {
+	// goto and labels need a second CFG-resolution pass (arbitrary jumps
+	// instead of structured fallthrough/branch edges) that this builder
+	// doesn't implement; bail out rather than report on a CFG that's
+	// silently wrong for that function. Every other function in the file
+	// is still analyzed.
+	if hasGotoOrLabel(body) {
+		return nil
+	}
+
+	builder := &cfgBuilder{
+		funcStart:    funcNode.Pos(),
+		funcEnd:      funcNode.End(),
+		namedResults: namedResultIdents(funcNode),
+	}
+	exit := builder.newBlock()
+	builder.exit = exit
+
+	block, ok := body.(*ast.BlockStmt)
+	if !ok {
+		return nil
+	}
+	entry := builder.build(block.List, exit)
+	_ = entry
+
+	live := computeLiveness(builder.blocks, exit, builder.funcStart, builder.funcEnd)
+
+	var issues []types.Issue
+	for _, b := range builder.blocks {
+		issues = append(issues, reportDeadStores(file, b, live.out[b], fset, builder.funcStart, builder.funcEnd)...)
+	}
+	return issues
+}
+
+// block is one straight-line run of statements/expressions in the CFG this
+// package builds for dead-store analysis.
+type block struct {
+	instrs []ast.Node
+	succs  []*block
+}
+
+// loopCtx records where an unlabeled break/continue inside a loop body
+// should jump to.
+type loopCtx struct {
+	continueTarget *block
+	breakTarget    *block
+}
+
+// cfgBuilder constructs a block CFG for one function body via
+// recursive-descent over its statement lists, threading each list's
+// fallthrough successor in as `next` the way a textbook CFG builder does.
+// funcStart/funcEnd bound the function so objDeclaredIn can tell this
+// function's own locals and parameters apart from package-level
+// declarations and outer-scope closure captures.
+type cfgBuilder struct {
+	blocks    []*block
+	exit      *block
+	loopStack []loopCtx
+	// breakStack additionally covers switch/select, which break can
+	// target but continue cannot.
+	breakStack []*block
+	funcStart  token.Pos
+	funcEnd    token.Pos
+
+	// namedResults holds the function's named return identifiers, if any.
+	// A bare `return` implicitly reads every one of them, so the ReturnStmt
+	// case treats them as uses even though they don't appear in s.Results.
+	namedResults []*ast.Ident
+}
+
+func (b *cfgBuilder) newBlock() *block {
+	blk := &block{}
+	b.blocks = append(b.blocks, blk)
+	return blk
+}
+
+// build lays out stmts into blocks, wiring the final block's fallthrough
+// edge to next, and returns the entry block for this statement list.
+func (b *cfgBuilder) build(stmts []ast.Stmt, next *block) *block {
+	cur := b.newBlock()
+	entry := cur
+
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.IfStmt:
+			if s.Init != nil {
+				cur.instrs = append(cur.instrs, s.Init)
+			}
+			cur.instrs = append(cur.instrs, s.Cond)
+
+			join := b.newBlock()
+			thenEntry := b.build(s.Body.List, join)
+
+			var elseEntry *block
+			switch e := s.Else.(type) {
+			case nil:
+				elseEntry = join
+			case *ast.BlockStmt:
+				elseEntry = b.build(e.List, join)
+			default:
+				elseEntry = b.build([]ast.Stmt{e}, join)
+			}
+
+			cur.succs = []*block{thenEntry, elseEntry}
+			cur = join
+
+		case *ast.ForStmt:
+			if s.Init != nil {
+				cur.instrs = append(cur.instrs, s.Init)
+			}
+
+			header := b.newBlock()
+			cur.succs = []*block{header}
+
+			exitBlk := b.newBlock()
+			post := b.newBlock()
+			if s.Post != nil {
+				post.instrs = append(post.instrs, s.Post)
+			}
+			post.succs = []*block{header}
+
+			b.loopStack = append(b.loopStack, loopCtx{continueTarget: post, breakTarget: exitBlk})
+			b.breakStack = append(b.breakStack, exitBlk)
+			bodyEntry := b.build(s.Body.List, post)
+			b.loopStack = b.loopStack[:len(b.loopStack)-1]
+			b.breakStack = b.breakStack[:len(b.breakStack)-1]
+
+			if s.Cond != nil {
+				header.instrs = append(header.instrs, s.Cond)
+				header.succs = []*block{bodyEntry, exitBlk}
+			} else {
+				header.succs = []*block{bodyEntry}
+			}
+
+			cur = exitBlk
+
+		case *ast.RangeStmt:
+			header := b.newBlock()
+			cur.succs = []*block{header}
+			header.instrs = append(header.instrs, s)
+
+			exitBlk := b.newBlock()
+
+			b.loopStack = append(b.loopStack, loopCtx{continueTarget: header, breakTarget: exitBlk})
+			b.breakStack = append(b.breakStack, exitBlk)
+			bodyEntry := b.build(s.Body.List, header)
+			b.loopStack = b.loopStack[:len(b.loopStack)-1]
+			b.breakStack = b.breakStack[:len(b.breakStack)-1]
+
+			header.succs = []*block{bodyEntry, exitBlk}
+			cur = exitBlk
+
+		case *ast.SwitchStmt:
+			if s.Init != nil {
+				cur.instrs = append(cur.instrs, s.Init)
+			}
+			if s.Tag != nil {
+				cur.instrs = append(cur.instrs, s.Tag)
+			}
+
+			join := b.newBlock()
+			b.breakStack = append(b.breakStack, join)
+
+			var caseEntries []*block
+			hasDefault := false
+			for _, c := range s.Body.List {
+				clause := c.(*ast.CaseClause)
+				if clause.List == nil {
+					hasDefault = true
+				}
+				for _, e := range clause.List {
+					cur.instrs = append(cur.instrs, e)
+				}
+				caseEntries = append(caseEntries, b.build(clause.Body, join))
+			}
+			b.breakStack = b.breakStack[:len(b.breakStack)-1]
+
+			if !hasDefault {
+				caseEntries = append(caseEntries, join)
+			}
+			cur.succs = caseEntries
+			if len(caseEntries) == 0 {
+				cur.succs = []*block{join}
+			}
+			cur = join
+
+		case *ast.TypeSwitchStmt:
+			if s.Init != nil {
+				cur.instrs = append(cur.instrs, s.Init)
+			}
+			cur.instrs = append(cur.instrs, s.Assign)
+
+			join := b.newBlock()
+			b.breakStack = append(b.breakStack, join)
+
+			var caseEntries []*block
+			hasDefault := false
+			for _, c := range s.Body.List {
+				clause := c.(*ast.CaseClause)
+				if clause.List == nil {
+					hasDefault = true
+				}
+				caseEntries = append(caseEntries, b.build(clause.Body, join))
+			}
+			b.breakStack = b.breakStack[:len(b.breakStack)-1]
+
+			if !hasDefault {
+				caseEntries = append(caseEntries, join)
+			}
+			cur.succs = caseEntries
+			if len(caseEntries) == 0 {
+				cur.succs = []*block{join}
+			}
+			cur = join
+
+		case *ast.SelectStmt:
+			join := b.newBlock()
+			b.breakStack = append(b.breakStack, join)
+
+			var commEntries []*block
+			for _, c := range s.Body.List {
+				clause := c.(*ast.CommClause)
+				body := clause.Body
+				if clause.Comm != nil {
+					body = append([]ast.Stmt{clause.Comm}, body...)
+				}
+				commEntries = append(commEntries, b.build(body, join))
+			}
+			b.breakStack = b.breakStack[:len(b.breakStack)-1]
+
+			cur.succs = commEntries
+			if len(commEntries) == 0 {
+				cur.succs = []*block{join}
+			}
+			cur = join
+
+		case *ast.BlockStmt:
+			join := b.newBlock()
+			entryBlk := b.build(s.List, join)
+			cur.succs = []*block{entryBlk}
+			cur = join
+
+		case *ast.ReturnStmt:
+			if len(s.Results) == 0 {
+				// Bare return: a named result's last assigned value flows
+				// out through it, so it's a read even though the return
+				// statement itself mentions no identifiers.
+				for _, id := range b.namedResults {
+					cur.instrs = append(cur.instrs, id)
+				}
+			}
+			for _, e := range s.Results {
+				cur.instrs = append(cur.instrs, e)
+			}
+			cur.succs = []*block{b.exit}
+			cur = b.newBlock() // unreachable tail, if any
+
+		case *ast.BranchStmt:
+			if s.Label != nil {
+				// Labeled break/continue needs the same label resolution
+				// this builder skips goto for; analyzeFuncLiveness already
+				// bails out for any function containing a label, so this
+				// branch is unreachable in practice - kept defensive.
+				cur = b.newBlock()
+				continue
+			}
+
+			var target *block
+			switch s.Tok {
+			case token.CONTINUE:
+				if len(b.loopStack) > 0 {
+					target = b.loopStack[len(b.loopStack)-1].continueTarget
+				}
+			case token.BREAK:
+				if len(b.breakStack) > 0 {
+					target = b.breakStack[len(b.breakStack)-1]
+				}
+			}
+
+			if target != nil {
+				cur.succs = []*block{target}
+			}
+			cur = b.newBlock()
+
+		default:
+			cur.instrs = append(cur.instrs, stmt)
+		}
+	}
+
+	cur.succs = []*block{next}
+	return entry
+}
+
+// namedResultIdents returns funcNode's named return identifiers, or nil if
+// it has none (an *ast.FuncDecl or *ast.FuncLit with unnamed, or no,
+// results). Go requires either all results to be named or none, so one
+// named field is enough to know every result is.
+func namedResultIdents(funcNode ast.Node) []*ast.Ident {
+	var typ *ast.FuncType
+	switch f := funcNode.(type) {
+	case *ast.FuncDecl:
+		typ = f.Type
+	case *ast.FuncLit:
+		typ = f.Type
+	}
+	if typ == nil || typ.Results == nil {
+		return nil
+	}
+
+	var idents []*ast.Ident
+	for _, field := range typ.Results.List {
+		idents = append(idents, field.Names...)
+	}
+	return idents
+}
+
+// hasGotoOrLabel reports whether node contains a goto or a labeled
+// statement anywhere in its subtree.
+func hasGotoOrLabel(node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.LabeledStmt:
+			found = true
+		case *ast.BranchStmt:
+			if s.Tok == token.GOTO {
+				found = true
+			}
+		case *ast.FuncLit:
+			// A nested closure's own gotos/labels don't affect this
+			// function's CFG; it's analyzed separately.
+			return false
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,317 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// varSet is a liveness set keyed by *ast.Object identity rather than name,
+// so shadowed variables in different scopes are never confused with each
+// other.
+type varSet map[*ast.Object]bool
+
+func (s varSet) clone() varSet {
+	out := make(varSet, len(s))
+	for k := range s {
+		out[k] = true
+	}
+	return out
+}
+
+func (s varSet) equal(other varSet) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for k := range s {
+		if !other[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// liveness holds the fixed-point live-in/live-out sets per block. start/end
+// bound the function the blocks belong to, so the dataflow only ever tracks
+// defs of that function's own locals and parameters - package-level vars
+// and outer-scope closure captures are read (kept live) but never
+// considered killed by this function.
+type liveness struct {
+	in    map[*block]varSet
+	out   map[*block]varSet
+	start token.Pos
+	end   token.Pos
+}
+
+// computeLiveness runs backward dataflow to a fixed point over blocks. The
+// synthetic exit block always has an empty live-out set.
+func computeLiveness(blocks []*block, exit *block, start, end token.Pos) *liveness {
+	lv := &liveness{in: make(map[*block]varSet), out: make(map[*block]varSet), start: start, end: end}
+	for _, b := range blocks {
+		lv.in[b] = varSet{}
+		lv.out[b] = varSet{}
+	}
+
+	changed := true
+	for changed {
+		changed = false
+		for _, b := range blocks {
+			if b == exit {
+				continue
+			}
+
+			out := varSet{}
+			for _, succ := range b.succs {
+				for v := range lv.in[succ] {
+					out[v] = true
+				}
+			}
+
+			in, _ := transfer(b.instrs, out, nil, lv.start, lv.end)
+
+			if !in.equal(lv.in[b]) {
+				lv.in[b] = in
+				changed = true
+			}
+			lv.out[b] = out
+		}
+	}
+
+	return lv
+}
+
+// transfer replays a block's instructions backward starting from liveAfter
+// (the block's live-out set), returning the resulting live-in set. When
+// report is non-nil, every def found dead at that point (not live,
+// declared within [start, end], and not blank) is appended to it as an
+// Issue - used for the final reporting pass once liveness has converged.
+func transfer(instrs []ast.Node, liveAfter varSet, report *[]types.Issue, start, end token.Pos) (varSet, []types.Issue) {
+	live := liveAfter.clone()
+	var issues []types.Issue
+
+	for i := len(instrs) - 1; i >= 0; i-- {
+		defs, uses := defUse(instrs[i], start, end)
+
+		for _, d := range defs {
+			if !live[d.obj] {
+				issues = append(issues, types.Issue{
+					Type:        types.TypeCodeSmell,
+					Severity:    types.SeverityMedium,
+					Rule:        "ineffectual_assignment",
+					Message:     "Ineffectual assignment to " + d.name,
+					Description: "This value is never read before it is overwritten or goes out of scope",
+					Impact:      types.IssueImpact{EffortMinutes: 3},
+					Line:        d.pos,
+				})
+			}
+			delete(live, d.obj)
+		}
+		for _, u := range uses {
+			live[u] = true
+		}
+	}
+
+	if report != nil {
+		*report = append(*report, issues...)
+	}
+
+	return live, issues
+}
+
+// reportDeadStores replays b with its converged live-out set and emits one
+// issue per dead def found, positioned and filed via fset.
+func reportDeadStores(file string, b *block, liveOut varSet, fset *token.FileSet, start, end token.Pos) []types.Issue {
+	var raw []types.Issue
+	_, raw = transfer(b.instrs, liveOut, &raw, start, end)
+
+	issues := make([]types.Issue, 0, len(raw))
+	for _, issue := range raw {
+		pos := fset.Position(token.Pos(issue.Line))
+		issue.File = file
+		issue.Line = pos.Line
+		issue.Column = pos.Column
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// namedDef is one identifier assigned by an instruction, carrying enough to
+// report it without re-deriving the identifier later.
+type namedDef struct {
+	obj  *ast.Object
+	name string
+	pos  int // token.Pos stashed as int so it can ride through types.Issue.Line until reportDeadStores resolves it
+}
+
+// defUse returns the variables an instruction defines (assigns to) and uses
+// (reads), restricted to *ast.Object-resolved local variables - package
+// level declarations and identifiers the parser couldn't resolve are
+// ignored, so this only ever reports on a function's own locals and
+// parameters.
+func defUse(n ast.Node, start, end token.Pos) ([]namedDef, []*ast.Object) {
+	var defs []namedDef
+	var uses []*ast.Object
+
+	addUse := func(e ast.Expr) {
+		for _, id := range identsIn(e) {
+			if obj := localObject(id); obj != nil {
+				uses = append(uses, obj)
+			}
+		}
+	}
+
+	switch s := n.(type) {
+	case *ast.AssignStmt:
+		for _, rhs := range s.Rhs {
+			addUse(rhs)
+		}
+		for i, lhs := range s.Lhs {
+			id, ok := lhs.(*ast.Ident)
+			if !ok {
+				addUse(lhs)
+				continue
+			}
+			if id.Name == "_" {
+				continue
+			}
+			obj := localObject(id)
+			if obj == nil {
+				continue
+			}
+			if s.Tok != token.ASSIGN && s.Tok != token.DEFINE {
+				// Compound assignment (+=, -=, ...) reads the prior
+				// value as part of computing the new one.
+				uses = append(uses, obj)
+			}
+			_ = i
+			if declaredWithin(obj, start, end) {
+				defs = append(defs, namedDef{obj: obj, name: id.Name, pos: int(id.Pos())})
+			}
+		}
+
+	case *ast.IncDecStmt:
+		if id, ok := s.X.(*ast.Ident); ok && id.Name != "_" {
+			if obj := localObject(id); obj != nil {
+				uses = append(uses, obj)
+				if declaredWithin(obj, start, end) {
+					defs = append(defs, namedDef{obj: obj, name: id.Name, pos: int(id.Pos())})
+				}
+			}
+		}
+
+	case *ast.DeclStmt:
+		gen, ok := s.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			break
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Values) == 0 {
+				continue
+			}
+			for _, v := range vs.Values {
+				addUse(v)
+			}
+			for _, name := range vs.Names {
+				if name.Name == "_" {
+					continue
+				}
+				if obj := localObject(name); obj != nil {
+					defs = append(defs, namedDef{obj: obj, name: name.Name, pos: int(name.Pos())})
+				}
+			}
+		}
+
+	case *ast.RangeStmt:
+		addUse(s.X)
+		if s.Tok == token.DEFINE || s.Tok == token.ASSIGN {
+			if id, ok := s.Key.(*ast.Ident); ok && id.Name != "_" {
+				if obj := localObject(id); obj != nil {
+					defs = append(defs, namedDef{obj: obj, name: id.Name, pos: int(id.Pos())})
+				}
+			}
+			if id, ok := s.Value.(*ast.Ident); ok && id.Name != "_" {
+				if obj := localObject(id); obj != nil {
+					defs = append(defs, namedDef{obj: obj, name: id.Name, pos: int(id.Pos())})
+				}
+			}
+		}
+
+	case *ast.SendStmt:
+		addUse(s.Chan)
+		addUse(s.Value)
+
+	case ast.Expr:
+		addUse(s)
+
+	case *ast.ExprStmt:
+		addUse(s.X)
+
+	case *ast.GoStmt:
+		addUse(s.Call)
+
+	case *ast.DeferStmt:
+		addUse(s.Call)
+
+	case *ast.LabeledStmt:
+		// Unreachable: analyzeFuncLiveness bails out of any function
+		// containing a label before the CFG is built.
+
+	default:
+		// Any other statement (e.g. a bare expression appended as a
+		// condition) - fall back to a conservative full-subtree scan so an
+		// unhandled shape never silently vanishes from the dataflow.
+		ast.Inspect(n, func(m ast.Node) bool {
+			if id, ok := m.(*ast.Ident); ok {
+				if obj := localObject(id); obj != nil {
+					uses = append(uses, obj)
+				}
+			}
+			return true
+		})
+	}
+
+	return defs, uses
+}
+
+// identsIn returns every *ast.Ident leaf in e's subtree.
+func identsIn(e ast.Expr) []*ast.Ident {
+	var out []*ast.Ident
+	if e == nil {
+		return out
+	}
+	ast.Inspect(e, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			out = append(out, id)
+		}
+		return true
+	})
+	return out
+}
+
+// localObject returns id's resolved *ast.Object when the parser identified
+// it as a variable, or nil for package-level names, imports, types,
+// functions, and identifiers the parser couldn't resolve (including ones
+// from a different file, where go/parser never sets Obj).
+func localObject(id *ast.Ident) *ast.Object {
+	if id.Obj == nil || id.Obj.Kind != ast.Var {
+		return nil
+	}
+	return id.Obj
+}
+
+// declaredWithin reports whether obj was declared inside [start, end) -
+// the current function's own source range. A var object resolves true for
+// this function's own locals and parameters; it resolves false for
+// package-level variables and for variables an enclosing function declares
+// that a nested closure only captures, so assignments to those are never
+// treated as a def this function's liveness analysis can kill.
+func declaredWithin(obj *ast.Object, start, end token.Pos) bool {
+	declNode, ok := obj.Decl.(ast.Node)
+	if !ok {
+		return false
+	}
+	pos := declNode.Pos()
+	return pos >= start && pos < end
+}
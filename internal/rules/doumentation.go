@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"strings"
@@ -46,6 +47,7 @@ func (r *DocumentationRule) Check(file string, node ast.Node, content string, fs
 						Message:     "Missing function documentation",
 						Description: "Exported functions should have documentation comments",
 						Impact:      types.IssueImpact{EffortMinutes: 3},
+						Fixes:       []types.SuggestedFix{docStubFix(file, lines, i, funcName)},
 					})
 				}
 			}
@@ -55,6 +57,35 @@ func (r *DocumentationRule) Check(file string, node ast.Node, content string, fs
 	return issues
 }
 
+// docStubFix proposes inserting a minimal "// FuncName ..." comment
+// immediately above lines[i] (the line declaring funcName), matching that
+// line's own indentation. It's the safe, mechanical half of what a real
+// doc comment needs - a human still has to fill in what "..." means - but
+// it's enough to get an exported identifier past `go vet`'s comment-style
+// checks and out of this rule's own report.
+func docStubFix(file string, lines []string, i int, funcName string) types.SuggestedFix {
+	offset := 0
+	for _, l := range lines[:i] {
+		offset += len(l) + 1 // +1 for the "\n" strings.Split consumed
+	}
+
+	indent := leadingWhitespace(lines[i])
+	stub := fmt.Sprintf("%s// %s ...\n", indent, funcName)
+
+	return types.SuggestedFix{
+		Message: fmt.Sprintf("Insert a doc comment stub above %s", funcName),
+		Edits: []types.TextEdit{
+			{File: file, Start: offset, End: offset, NewText: stub},
+		},
+	}
+}
+
+// leadingWhitespace returns s's leading run of spaces/tabs, so a stub
+// comment matches the indentation of the declaration it's inserted above.
+func leadingWhitespace(s string) string {
+	return s[:len(s)-len(strings.TrimLeft(s, " \t"))]
+}
+
 func (r *DocumentationRule) extractFunctionName(line string) string {
 	// Simple extraction of function name from function declaration
 	parts := strings.Fields(line)
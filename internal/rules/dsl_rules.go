@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// NewDSLShortFunctionNameRule builds, via RuleBuilder, a Rule equivalent to
+// NamingRule's short-name half of checkFunctionNaming: it flags a
+// *ast.FuncDecl whose name is shorter than two characters, skipping the
+// same special-cased names (main, init, Test*, Benchmark*) that
+// checkFunctionNaming does. It's a reference implementation showing the DSL
+// reproducing an existing hand-written check byte-for-byte in its issues;
+// see dsl_test.go for a test asserting exactly that. It is not registered
+// by NewEngine - NamingRule already covers this under rule ID "naming", and
+// registering both would double-report every short function name.
+func NewDSLShortFunctionNameRule() Rule {
+	return NewRule[*ast.FuncDecl]("dsl_short_function_name").
+		Named("Short Function Name (DSL)", "Checks for function names that are too short to be descriptive").
+		When(func(fn *ast.FuncDecl, fset *token.FileSet) bool {
+			return fn.Name != nil && !isSpecialFunctionName(fn.Name.Name) && len(fn.Name.Name) < 2
+		}).
+		ReportFunc(func(fn *ast.FuncDecl, fset *token.FileSet) types.Issue {
+			return types.Issue{
+				Type:        types.TypeCodeSmell,
+				Severity:    types.SeverityLow,
+				Message:     "Function name too short",
+				Description: fmt.Sprintf("Function name '%s' should be more descriptive", fn.Name.Name),
+				Impact:      types.IssueImpact{EffortMinutes: 2},
+			}
+		}).
+		Build()
+}
+
+// NewDSLLargeFunctionRule builds, via RuleBuilder, a Rule equivalent to
+// ComplexityRule.checkFunctionSize: it flags a *ast.FuncDecl whose body
+// spans more lines than max, escalating to high severity past 2x max - the
+// same thresholds and severity curve checkFunctionSize uses, parameterized
+// the same way NewComplexityRuleWithThreshold parameterizes ComplexityRule
+// rather than hard-coding defaultFunctionLinesMax. See dsl_test.go for a
+// test asserting its output matches ComplexityRule's for the same input.
+func NewDSLLargeFunctionRule(max int) Rule {
+	if max <= 0 {
+		max = defaultFunctionLinesMax
+	}
+
+	return NewRule[*ast.FuncDecl]("dsl_large_function").
+		Named("Large Function (DSL)", "Checks for functions that are too long").
+		When(func(fn *ast.FuncDecl, fset *token.FileSet) bool {
+			return fn.Body != nil && fn.Name != nil && functionLineCount(fn, fset) > max
+		}).
+		ReportFunc(func(fn *ast.FuncDecl, fset *token.FileSet) types.Issue {
+			lines := functionLineCount(fn, fset)
+
+			severity := types.SeverityMedium
+			if lines > max*2 {
+				severity = types.SeverityHigh
+			}
+
+			return types.Issue{
+				Type:        types.TypeCodeSmell,
+				Severity:    severity,
+				Message:     "Function too large",
+				Description: fmt.Sprintf("Function '%s' has %d lines (threshold: %d)", fn.Name.Name, lines, max),
+				Impact:      types.IssueImpact{EffortMinutes: lines / 10},
+			}
+		}).
+		Build()
+}
+
+// isSpecialFunctionName mirrors checkFunctionNaming's skip list for names
+// that are exempt from this package's naming checks.
+func isSpecialFunctionName(name string) bool {
+	if name == "main" || name == "init" {
+		return true
+	}
+	return strings.HasPrefix(name, "Test") || strings.HasPrefix(name, "Benchmark")
+}
+
+// functionLineCount returns fn's body line span the same way
+// checkFunctionSize computes it: the difference between its closing and
+// opening brace lines.
+func functionLineCount(fn *ast.FuncDecl, fset *token.FileSet) int {
+	start := fset.Position(fn.Body.Lbrace)
+	end := fset.Position(fn.Body.Rbrace)
+	return end.Line - start.Line
+}
@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// issuesByRule returns the subset of issues whose Rule field matches
+// ruleName, ignoring Category (which AnalyzeFile, not Check, assigns).
+func issuesByRule(issues []types.Issue, ruleName string) []types.Issue {
+	var out []types.Issue
+	for _, issue := range issues {
+		if issue.Rule == ruleName {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+func TestDSLShortFunctionNameRuleMatchesNamingRule(t *testing.T) {
+	code := `package main
+
+func a() {}
+
+func longEnough() {}
+
+func main() {}
+`
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	want := issuesByRule(NewNamingRule().Check("test.go", node, code, fset), "short_function_name")
+	got := NewDSLShortFunctionNameRule().Check("test.go", node, code, fset)
+
+	if len(want) != 1 || len(got) != 1 {
+		t.Fatalf("want 1 short_function_name issue from each rule, got NamingRule=%d DSL=%d", len(want), len(got))
+	}
+
+	if got[0].Line != want[0].Line || got[0].Severity != want[0].Severity ||
+		got[0].Message != want[0].Message || got[0].Description != want[0].Description {
+		t.Errorf("DSL rule issue = %+v, want equivalent to NamingRule issue %+v", got[0], want[0])
+	}
+}
+
+func TestDSLLargeFunctionRuleMatchesComplexityRule(t *testing.T) {
+	code := "package main\n\nfunc Big() int {\n" +
+		"\tx := 0\n\tx++\n\tx++\n\tx++\n\tx++\n\tx++\n\tx++\n\treturn x\n}\n"
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	complexityRule := NewComplexityRuleFromConfig(ComplexityConfig{FunctionLinesMax: 3})
+	want := issuesByRule(complexityRule.Check("test.go", node, code, fset), "large_function")
+	got := NewDSLLargeFunctionRule(3).Check("test.go", node, code, fset)
+
+	if len(want) != 1 || len(got) != 1 {
+		t.Fatalf("want 1 large_function issue from each rule, got ComplexityRule=%d DSL=%d", len(want), len(got))
+	}
+
+	if got[0].Line != want[0].Line || got[0].Severity != want[0].Severity ||
+		got[0].Message != want[0].Message || got[0].Description != want[0].Description ||
+		got[0].Impact != want[0].Impact {
+		t.Errorf("DSL rule issue = %+v, want equivalent to ComplexityRule issue %+v", got[0], want[0])
+	}
+}
+
+func TestRuleBuilderOrCombinesGroupsDisjunctively(t *testing.T) {
+	code := `package main
+
+func Foo() {}
+
+func Bar() {}
+
+func Baz() {}
+`
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	rule := NewRule[*ast.FuncDecl]("dsl_or_test").
+		Named("DSL Or Test", "matches Foo or Bar, not Baz").
+		When(func(fn *ast.FuncDecl, fset *token.FileSet) bool { return fn.Name.Name == "Foo" }).
+		Or(func(fn *ast.FuncDecl, fset *token.FileSet) bool { return fn.Name.Name == "Bar" }).
+		Report("matched", types.SeverityLow, types.TypeCodeSmell).
+		Build()
+
+	issues := rule.Check("test.go", node, code, fset)
+	if len(issues) != 2 {
+		t.Fatalf("Check() returned %d issues, want 2 (Foo and Bar, not Baz)", len(issues))
+	}
+}
+
+func TestRuleBuilderAndRequiresAllPredicates(t *testing.T) {
+	code := `package main
+
+func Foo() {}
+`
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	rule := NewRule[*ast.FuncDecl]("dsl_and_test").
+		When(func(fn *ast.FuncDecl, fset *token.FileSet) bool { return fn.Name.Name == "Foo" }).
+		And(func(fn *ast.FuncDecl, fset *token.FileSet) bool { return false }).
+		Report("unreachable", types.SeverityLow, types.TypeCodeSmell).
+		Build()
+
+	if issues := rule.Check("test.go", node, code, fset); len(issues) != 0 {
+		t.Errorf("Check() returned %d issues, want 0 since the And'd predicate always fails", len(issues))
+	}
+}
+
+func TestRuleBuilderWithFixAttachesSuggestedFix(t *testing.T) {
+	code := `package main
+
+func Foo() {}
+`
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	rule := NewRule[*ast.FuncDecl]("dsl_fix_test").
+		When(func(fn *ast.FuncDecl, fset *token.FileSet) bool { return true }).
+		Report("flagged", types.SeverityLow, types.TypeCodeSmell).
+		WithFix("rename to Bar", true, func(fn *ast.FuncDecl, fset *token.FileSet) []types.TextEdit {
+			start := fset.Position(fn.Name.Pos()).Offset
+			end := fset.Position(fn.Name.End()).Offset
+			return []types.TextEdit{{File: "test.go", Start: start, End: end, NewText: "Bar"}}
+		}).
+		Build()
+
+	issues := rule.Check("test.go", node, code, fset)
+	if len(issues) != 1 {
+		t.Fatalf("Check() returned %d issues, want 1", len(issues))
+	}
+
+	fixes := issues[0].Fixes
+	if len(fixes) != 1 || !fixes[0].Unsafe || len(fixes[0].Edits) != 1 || fixes[0].Edits[0].NewText != "Bar" {
+		t.Errorf("Check() issue Fixes = %+v, want one unsafe fix renaming to Bar", fixes)
+	}
+}
+
+func TestRuleBuilderNoPredicatesMatchesEveryNode(t *testing.T) {
+	code := `package main
+
+func Foo() {}
+
+func Bar() {}
+`
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	rule := NewRule[*ast.FuncDecl]("dsl_no_predicate_test").
+		Report("every function", types.SeverityLow, types.TypeCodeSmell).
+		Build()
+
+	if issues := rule.Check("test.go", node, code, fset); len(issues) != 2 {
+		t.Errorf("Check() returned %d issues, want 2 (one per FuncDecl)", len(issues))
+	}
+}
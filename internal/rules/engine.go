@@ -3,7 +3,10 @@ package rules
 import (
 	"go/ast"
 	"go/token"
+	"sync"
 
+	"github.com/chaksack/nada/internal/suppress"
+	"github.com/chaksack/nada/internal/typecheck"
 	"github.com/chaksack/nada/internal/types"
 )
 
@@ -15,15 +18,48 @@ type Rule interface {
 	Check(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue
 }
 
+// PackageRule is implemented by rules that need to see every file of a
+// package at once rather than one file in isolation - whether the package
+// has exactly one package comment, or whether every method of a type
+// agrees on a receiver name, can't be decided from a single *ast.File. It
+// embeds Rule so a PackageRule is cataloged (ID/Name/Description) the same
+// way file-level rules are; its Check is simply never called by
+// AnalyzeFile, only CheckPackage is, via AnalyzePackages.
+type PackageRule interface {
+	Rule
+	CheckPackage(pkgPath string, files []*ast.File, fset *token.FileSet) []types.Issue
+}
+
+// TypedRule is implemented by rules that need type information - and
+// potentially every package of the module, not just one - to decide
+// whether something is an issue: whether an exported identifier is ever
+// referenced outside its own package, whether a call's result genuinely
+// implements error rather than merely being named err. It embeds Rule for
+// the same reason PackageRule does; CheckProgram is invoked once per run,
+// via AnalyzeProgram, against a *typecheck.Program covering the whole
+// module, not per file or per package.
+type TypedRule interface {
+	Rule
+	CheckProgram(prog *typecheck.Program) []types.Issue
+}
+
 // Engine manages and executes analysis rules
 type Engine struct {
-	rules []Rule
+	rules           []Rule
+	packageRules    []PackageRule
+	typedRules      []TypedRule
+	externalLinters []ExternalLinter
+	disabled        map[string]bool
+
+	suppressionsMu     sync.Mutex
+	unusedSuppressions int
 }
 
 // NewEngine creates a new rule engine with default rules
 func NewEngine() *Engine {
 	engine := &Engine{
-		rules: make([]Rule, 0),
+		rules:    make([]Rule, 0),
+		disabled: make(map[string]bool),
 	}
 
 	// Register default rules
@@ -33,6 +69,10 @@ func NewEngine() *Engine {
 	engine.RegisterRule(NewStructureRule())
 	engine.RegisterRule(NewDocumentationRule())
 	engine.RegisterRule(NewErrorHandlingRule())
+	engine.RegisterRule(NewIneffectualAssignmentRule())
+	engine.RegisterRule(NewIneffAssignRule())
+	engine.RegisterPackageRule(NewStyleRule())
+	engine.RegisterTypedRule(NewUnusedExportRule())
 
 	return engine
 }
@@ -42,17 +82,174 @@ func (e *Engine) RegisterRule(rule Rule) {
 	e.rules = append(e.rules, rule)
 }
 
-// GetRules returns all registered rules
+// RegisterPackageRule adds a new package-scoped rule to the engine.
+func (e *Engine) RegisterPackageRule(rule PackageRule) {
+	e.packageRules = append(e.packageRules, rule)
+}
+
+// RegisterTypedRule adds a new type-aware, whole-module rule to the engine.
+func (e *Engine) RegisterTypedRule(rule TypedRule) {
+	e.typedRules = append(e.typedRules, rule)
+}
+
+// RegisterExternal adds a third-party linter (see ExternalLinter/ToolAdapter
+// in external_linters.go) to run alongside options.Linters' named, built-in
+// tools - the engine's equivalent of RegisterRule for a check that shells
+// out to its own binary rather than walking an *ast.File.
+func (e *Engine) RegisterExternal(tool ExternalLinter) {
+	e.externalLinters = append(e.externalLinters, tool)
+}
+
+// GetExternalLinters returns the linters added via RegisterExternal.
+func (e *Engine) GetExternalLinters() []ExternalLinter {
+	return e.externalLinters
+}
+
+// ReplaceRule swaps out the registered rule with the same ID as rule for
+// rule itself, preserving its position (and enabled/disabled state), or
+// registers it if no rule with that ID exists yet. This is how a config's
+// per-rule thresholds take effect: a tuned ComplexityRule or StructureRule
+// replaces the default instance NewEngine registered.
+func (e *Engine) ReplaceRule(rule Rule) {
+	for i, existing := range e.rules {
+		if existing.ID() == rule.ID() {
+			e.rules[i] = rule
+			return
+		}
+	}
+	e.RegisterRule(rule)
+}
+
+// GetRules returns all registered file-level rules, including disabled
+// ones, so callers like `nada rules list` can show the full catalog.
 func (e *Engine) GetRules() []Rule {
 	return e.rules
 }
 
-// AnalyzeFile runs all rules against a file
+// GetPackageRules returns all registered package-scoped rules, including
+// disabled ones.
+func (e *Engine) GetPackageRules() []PackageRule {
+	return e.packageRules
+}
+
+// GetTypedRules returns all registered type-aware rules, including disabled
+// ones.
+func (e *Engine) GetTypedRules() []TypedRule {
+	return e.typedRules
+}
+
+// Rule looks up a registered rule by ID.
+func (e *Engine) Rule(id string) (Rule, bool) {
+	for _, rule := range e.rules {
+		if rule.ID() == id {
+			return rule, true
+		}
+	}
+	return nil, false
+}
+
+// SetEnabled enables or disables the rule with the given ID for subsequent
+// AnalyzeFile calls. Disabling an unknown ID is a no-op: a config listing a
+// rule pack's ID before that pack is loaded shouldn't be an error.
+func (e *Engine) SetEnabled(id string, enabled bool) {
+	if enabled {
+		delete(e.disabled, id)
+		return
+	}
+	e.disabled[id] = true
+}
+
+// IsEnabled reports whether the rule with the given ID will run.
+func (e *Engine) IsEnabled(id string) bool {
+	return !e.disabled[id]
+}
+
+// AnalyzeFile runs all enabled rules against a file. Each issue is tagged
+// with the ID of the rule that produced it (unless the rule already set
+// one), so downstream reporters can group findings back by rule engine.
+// Issues matching a //nada:ignore directive (see package suppress) are
+// dropped before returning; directives that matched nothing are counted
+// in UnusedSuppressions.
 func (e *Engine) AnalyzeFile(filePath string, node *ast.File, content string, fset *token.FileSet) []types.Issue {
 	var allIssues []types.Issue
 
 	for _, rule := range e.rules {
+		if e.disabled[rule.ID()] {
+			continue
+		}
+
 		issues := rule.Check(filePath, node, content, fset)
+		for i := range issues {
+			if issues[i].Category == "" {
+				issues[i].Category = rule.ID()
+			}
+		}
+		allIssues = append(allIssues, issues...)
+	}
+
+	suppressions := suppress.Parse(node, fset)
+	allIssues = suppressions.Filter(allIssues)
+
+	e.suppressionsMu.Lock()
+	e.unusedSuppressions += suppressions.UnusedCount()
+	e.suppressionsMu.Unlock()
+
+	return allIssues
+}
+
+// UnusedSuppressions returns the total number of //nada:ignore directives,
+// across every file AnalyzeFile has processed so far, that never matched an
+// issue.
+func (e *Engine) UnusedSuppressions() int {
+	e.suppressionsMu.Lock()
+	defer e.suppressionsMu.Unlock()
+	return e.unusedSuppressions
+}
+
+// AnalyzePackages runs all enabled package-scoped rules against each
+// package in pkgs, which maps a package's directory path to the parsed
+// files it contains. Issues are tagged with the producing rule's ID the
+// same way AnalyzeFile tags file-level issues.
+func (e *Engine) AnalyzePackages(pkgs map[string][]*ast.File, fset *token.FileSet) []types.Issue {
+	var allIssues []types.Issue
+
+	for _, rule := range e.packageRules {
+		if e.disabled[rule.ID()] {
+			continue
+		}
+
+		for pkgPath, files := range pkgs {
+			issues := rule.CheckPackage(pkgPath, files, fset)
+			for i := range issues {
+				if issues[i].Category == "" {
+					issues[i].Category = rule.ID()
+				}
+			}
+			allIssues = append(allIssues, issues...)
+		}
+	}
+
+	return allIssues
+}
+
+// AnalyzeProgram runs all enabled type-aware rules against prog, a whole
+// module loaded and type-checked by package typecheck. Issues are tagged
+// with the producing rule's ID the same way AnalyzeFile and AnalyzePackages
+// tag theirs.
+func (e *Engine) AnalyzeProgram(prog *typecheck.Program) []types.Issue {
+	var allIssues []types.Issue
+
+	for _, rule := range e.typedRules {
+		if e.disabled[rule.ID()] {
+			continue
+		}
+
+		issues := rule.CheckProgram(prog)
+		for i := range issues {
+			if issues[i].Category == "" {
+				issues[i].Category = rule.ID()
+			}
+		}
 		allIssues = append(allIssues, issues...)
 	}
 
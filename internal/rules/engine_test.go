@@ -1,12 +1,18 @@
 package rules
 
 import (
+	"errors"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/chaksack/nada/internal/typecheck"
 	"github.com/chaksack/nada/internal/types"
+	"github.com/chaksack/nada/internal/vulndb"
 )
 
 // Test the rule engine
@@ -210,6 +216,37 @@ func ComplexFunction(x int) int {
 	}
 }
 
+// Test NewComplexityRuleFromConfig applying a custom function-lines ceiling
+func TestComplexityRuleFromConfigFunctionLinesMax(t *testing.T) {
+	rule := NewComplexityRuleFromConfig(ComplexityConfig{FunctionLinesMax: 3})
+
+	code := `package main
+
+func Small() int {
+	x := 1
+	y := 2
+	return x + y
+}`
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	issues := rule.Check("test.go", node, code, fset)
+
+	found := false
+	for _, issue := range issues {
+		if issue.Rule == "large_function" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Check() expected a large_function issue once FunctionLinesMax is lowered to 3")
+	}
+}
+
 // Test SecurityRule
 func TestSecurityRule(t *testing.T) {
 	rule := NewSecurityRule()
@@ -233,8 +270,10 @@ var password = "secret123"`,
 			name: "SQL injection",
 			code: `package main
 import "fmt"
-func main() {
+func handler(db *sql.DB, r *http.Request) {
+	userInput := r.FormValue("id")
 	query := fmt.Sprintf("SELECT * FROM users WHERE id = %s", userInput)
+	db.Query(query)
 }`,
 			wantRule: "sql_injection",
 		},
@@ -277,6 +316,57 @@ const api_key = "sk-1234567890abcdef"`,
 	}
 }
 
+// TestSecurityRuleHardcodedSecretFix checks the hardcoded_secret fix wired
+// up in checkHardcodedSecrets: the literal is replaced with os.Getenv, and
+// since the file doesn't already import "os", a second edit adds it.
+func TestSecurityRuleHardcodedSecretFix(t *testing.T) {
+	rule := NewSecurityRule()
+
+	code := "package main\n\nvar password = \"hunter2222\"\n"
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	issues := rule.Check("test.go", node, code, fset)
+
+	var found *types.Issue
+	for i := range issues {
+		if issues[i].Rule == "hardcoded_secret" {
+			found = &issues[i]
+			break
+		}
+	}
+	if found == nil {
+		t.Fatalf("Check() did not report a hardcoded_secret issue: %+v", issues)
+	}
+
+	if len(found.Fixes) != 1 {
+		t.Fatalf("hardcoded_secret issue Fixes = %+v, want exactly one SuggestedFix", found.Fixes)
+	}
+	fix := found.Fixes[0]
+	if !fix.Unsafe {
+		t.Error("hardcoded_secret fix should be Unsafe (changes runtime behavior)")
+	}
+	if len(fix.Edits) != 2 {
+		t.Fatalf("hardcoded_secret fix Edits = %+v, want 2 (literal replacement + import)", fix.Edits)
+	}
+
+	literalEdit := fix.Edits[0]
+	if literalEdit.NewText != `os.Getenv("PASSWORD")` {
+		t.Errorf("literal edit NewText = %q, want os.Getenv(\"PASSWORD\")", literalEdit.NewText)
+	}
+	if code[literalEdit.Start:literalEdit.End] != `"hunter2222"` {
+		t.Errorf("literal edit range = %q, want the original quoted literal", code[literalEdit.Start:literalEdit.End])
+	}
+
+	importEdit := fix.Edits[1]
+	if importEdit.NewText != "\n\nimport \"os\"" {
+		t.Errorf("import edit NewText = %q, want an os import", importEdit.NewText)
+	}
+}
+
 // Test NamingRule
 func TestNamingRule(t *testing.T) {
 	rule := NewNamingRule()
@@ -395,6 +485,517 @@ func main() {}`,
 	}
 }
 
+// TestStructureRuleTodoCommentFixNormalizesFormat checks that a
+// non-canonical todo/fixme/hack comment's fix rewrites it to the "//
+// KEYWORD: message" form, and that an already-canonical one's fix is a
+// true no-op (NewText equals the original comment text).
+func TestStructureRuleTodoCommentFixNormalizesFormat(t *testing.T) {
+	rule := NewStructureRule()
+
+	code := "package main\n\n//fixme clean this up\nfunc main() {}\n"
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	issues := rule.Check("test.go", node, code, fset)
+
+	var found *types.Issue
+	for i := range issues {
+		if issues[i].Rule == "todo_comment" {
+			found = &issues[i]
+			break
+		}
+	}
+	if found == nil {
+		t.Fatalf("Check() did not report a todo_comment issue: %+v", issues)
+	}
+	if len(found.Fixes) != 1 || len(found.Fixes[0].Edits) != 1 {
+		t.Fatalf("todo_comment issue Fixes = %+v, want exactly one edit", found.Fixes)
+	}
+	if found.Fixes[0].Unsafe {
+		t.Error("todo_comment fix should not be Unsafe - it only rewrites comment text")
+	}
+
+	edit := found.Fixes[0].Edits[0]
+	if edit.NewText != "// FIXME: clean this up" {
+		t.Errorf("fix NewText = %q, want %q", edit.NewText, "// FIXME: clean this up")
+	}
+	if code[edit.Start:edit.End] != "//fixme clean this up" {
+		t.Errorf("fix range = %q, want the original comment text", code[edit.Start:edit.End])
+	}
+}
+
+func TestIneffectualAssignmentRule(t *testing.T) {
+	rule := NewIneffectualAssignmentRule()
+
+	if rule.ID() != "ineffassign" {
+		t.Errorf("IneffectualAssignmentRule.ID() = %v, want ineffassign", rule.ID())
+	}
+
+	tests := []struct {
+		name      string
+		code      string
+		wantCount int
+	}{
+		{
+			name: "overwritten before read",
+			code: `package main
+
+func main() {
+	x := 1
+	x = 2
+	println(x)
+}`,
+			wantCount: 1,
+		},
+		{
+			name: "read before overwrite",
+			code: `package main
+
+func main() {
+	x := 1
+	println(x)
+	x = 2
+	println(x)
+}`,
+			wantCount: 0,
+		},
+		{
+			name: "compound assignment reads the prior value",
+			code: `package main
+
+func main() {
+	x := 1
+	x += 2
+	println(x)
+}`,
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			node, err := parser.ParseFile(fset, "test.go", tt.code, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse test code: %v", err)
+			}
+
+			issues := rule.Check("test.go", node, tt.code, fset)
+
+			if len(issues) != tt.wantCount {
+				t.Errorf("IneffectualAssignmentRule.Check() = %d issues, want %d: %+v",
+					len(issues), tt.wantCount, issues)
+			}
+		})
+	}
+}
+
+func TestIneffAssignRule(t *testing.T) {
+	rule := NewIneffAssignRule()
+
+	if rule.ID() != "ineffectual_assignment" {
+		t.Errorf("IneffAssignRule.ID() = %v, want ineffectual_assignment", rule.ID())
+	}
+
+	tests := []struct {
+		name      string
+		code      string
+		wantCount int
+	}{
+		{
+			name: "dead store across if/else branches",
+			code: `package main
+
+func f(cond bool) int {
+	x := 1
+	if cond {
+		x = 2
+	} else {
+		x = 3
+	}
+	return 0
+}`,
+			// x := 1 is dead (both branches overwrite it before any read),
+			// and whichever branch's assignment the return statement
+			// doesn't read is also dead.
+			wantCount: 3,
+		},
+		{
+			name: "read in one branch keeps the assignment live",
+			code: `package main
+
+func f(cond bool) int {
+	x := 1
+	if cond {
+		println(x)
+	}
+	x = 2
+	return x
+}`,
+			wantCount: 0,
+		},
+		{
+			name: "dead store across loop iterations",
+			code: `package main
+
+func f(n int) int {
+	total := 0
+	for i := 0; i < n; i++ {
+		total = i
+	}
+	return total
+}`,
+			wantCount: 0,
+		},
+		{
+			name: "blank identifier target is never reported",
+			code: `package main
+
+func f() int {
+	_, err := g()
+	_ = err
+	return 0
+}
+
+func g() (int, error) {
+	return 0, nil
+}`,
+			wantCount: 0,
+		},
+		{
+			name: "multi-return assignment reports only the dead ident",
+			code: `package main
+
+func f() int {
+	a, b := g()
+	a = 1
+	println(a)
+	return b
+}
+
+func g() (int, int) {
+	return 0, 0
+}`,
+			wantCount: 1,
+		},
+		{
+			name: "write later read inside a closure is not flagged",
+			code: `package main
+
+func f() func() int {
+	x := 1
+	return func() int {
+		return x
+	}
+}`,
+			wantCount: 0,
+		},
+		{
+			name: "goto bails out of the whole function",
+			code: `package main
+
+func f() int {
+	x := 1
+	x = 2
+loop:
+	if x > 0 {
+		goto loop
+	}
+	return 0
+}`,
+			wantCount: 0,
+		},
+		{
+			name: "final assignment to a named return before a bare return is live",
+			code: `package main
+
+func f() (result int) {
+	result = 1
+	result = 2
+	return
+}`,
+			// The first assignment is dead (overwritten before the bare
+			// return reads it); the second is what the bare return reads.
+			wantCount: 1,
+		},
+		{
+			name: "named return overwritten by another assignment before a bare return",
+			code: `package main
+
+func f() (result int) {
+	result = 1
+	result = 2
+	result = 3
+	return
+}`,
+			wantCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			node, err := parser.ParseFile(fset, "test.go", tt.code, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse test code: %v", err)
+			}
+
+			issues := rule.Check("test.go", node, tt.code, fset)
+
+			if len(issues) != tt.wantCount {
+				t.Errorf("IneffAssignRule.Check() = %d issues, want %d: %+v",
+					len(issues), tt.wantCount, issues)
+			}
+
+			for _, issue := range issues {
+				if issue.Type != types.TypeCodeSmell {
+					t.Errorf("issue.Type = %v, want %v", issue.Type, types.TypeCodeSmell)
+				}
+				if issue.Severity != types.SeverityMedium {
+					t.Errorf("issue.Severity = %v, want %v", issue.Severity, types.SeverityMedium)
+				}
+			}
+		})
+	}
+}
+
+// fakeVulnSource is an in-memory vulndb.Source for tests, since real
+// VulnDBRule usage queries either the network or a local repo clone.
+type fakeVulnSource struct {
+	byModule map[string][]vulndb.Vuln
+}
+
+func (f *fakeVulnSource) Lookup(module string) ([]vulndb.Vuln, error) {
+	return f.byModule[module], nil
+}
+
+func TestVulnDBRule(t *testing.T) {
+	dir := t.TempDir()
+	goMod := "module example.com/app\n\ngo 1.21\n\nrequire github.com/example/vulnerable v1.0.0\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatalf("writing go.mod: %v", err)
+	}
+
+	source := &fakeVulnSource{byModule: map[string][]vulndb.Vuln{
+		"github.com/example/vulnerable": {
+			{
+				ID:      "GHSA-test-0001",
+				Summary: "test vulnerability",
+				Severity: []vulndb.Severity{
+					{Type: "CVSS_V3", Score: "9.1"},
+				},
+				Affected: []vulndb.Affected{
+					{
+						Package: vulndb.Package{Name: "github.com/example/vulnerable", Ecosystem: "Go"},
+						Ranges: []vulndb.Range{
+							{Type: "SEMVER", Events: []vulndb.Event{{Introduced: "0"}, {Fixed: "v1.0.1"}}},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	rule := NewVulnDBRule(source)
+
+	if rule.ID() != "vulndb" {
+		t.Errorf("VulnDBRule.ID() = %v, want vulndb", rule.ID())
+	}
+
+	code := `package main
+
+import "github.com/example/vulnerable/pkg"
+
+func main() {
+	pkg.Do()
+}
+`
+	file := filepath.Join(dir, "main.go")
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, file, code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	issues := rule.Check(file, node, code, fset)
+	if len(issues) != 1 {
+		t.Fatalf("Check() = %d issues, want 1: %+v", len(issues), issues)
+	}
+	if issues[0].Rule != "GHSA-test-0001" {
+		t.Errorf("Check() issue Rule = %v, want GHSA-test-0001", issues[0].Rule)
+	}
+	if issues[0].Severity != types.SeverityHigh {
+		t.Errorf("Check() issue Severity = %v, want %v", issues[0].Severity, types.SeverityHigh)
+	}
+}
+
+func TestVulnDBRuleNoGoMod(t *testing.T) {
+	dir := t.TempDir()
+	rule := NewVulnDBRule(&fakeVulnSource{})
+
+	code := `package main
+
+import "github.com/example/vulnerable/pkg"
+
+func main() {
+	pkg.Do()
+}
+`
+	file := filepath.Join(dir, "main.go")
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, file, code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	if issues := rule.Check(file, node, code, fset); len(issues) != 0 {
+		t.Errorf("Check() with no go.mod = %d issues, want 0: %+v", len(issues), issues)
+	}
+}
+
+// fakeRegoEvaluator is an in-memory RegoEvaluator for tests, since real
+// RegoRule usage shells out to the opa binary.
+type fakeRegoEvaluator struct {
+	results []RegoResult
+	err     error
+	gotPath string
+	gotAST  map[string]interface{}
+}
+
+func (f *fakeRegoEvaluator) Eval(policyPath string, input map[string]interface{}) ([]RegoResult, error) {
+	f.gotPath = policyPath
+	if ast, ok := input["ast"].(map[string]interface{}); ok {
+		f.gotAST = ast
+	}
+	return f.results, f.err
+}
+
+func TestRegoRule(t *testing.T) {
+	evaluator := &fakeRegoEvaluator{
+		results: []RegoResult{{Msg: "fmt.Println is banned", Line: 4, Column: 2, Rule: "no_println"}},
+	}
+	rule := NewRegoRule("no_println", "policies/no_println.rego", types.SeverityLow, types.TypeCodeSmell, evaluator)
+
+	if rule.ID() != "no_println" {
+		t.Errorf("RegoRule.ID() = %v, want no_println", rule.ID())
+	}
+
+	code := `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hi")
+}
+`
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	issues := rule.Check("test.go", node, code, fset)
+	if len(issues) != 1 {
+		t.Fatalf("Check() = %d issues, want 1: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Rule != "no_println" || issue.Message != "fmt.Println is banned" || issue.Line != 4 || issue.Column != 2 {
+		t.Errorf("Check() issue = %+v, unexpected", issue)
+	}
+	if issue.Severity != types.SeverityLow || issue.Type != types.TypeCodeSmell {
+		t.Errorf("Check() issue severity/type = %v/%v, want %v/%v", issue.Severity, issue.Type, types.SeverityLow, types.TypeCodeSmell)
+	}
+
+	if evaluator.gotPath != "policies/no_println.rego" {
+		t.Errorf("Eval() policyPath = %v, want policies/no_println.rego", evaluator.gotPath)
+	}
+	if evaluator.gotAST == nil {
+		t.Fatal("Eval() input.ast = nil, want the serialized AST")
+	}
+	if evaluator.gotAST["kind"] != "*ast.File" {
+		t.Errorf("Eval() input.ast[\"kind\"] = %v, want *ast.File", evaluator.gotAST["kind"])
+	}
+}
+
+func TestRegoRuleEvalError(t *testing.T) {
+	evaluator := &fakeRegoEvaluator{err: errors.New("opa: executable file not found in $PATH")}
+	rule := NewRegoRule("no_println", "policies/no_println.rego", types.SeverityLow, types.TypeCodeSmell, evaluator)
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", "package main", parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	if issues := rule.Check("test.go", node, "package main", fset); issues != nil {
+		t.Errorf("Check() with a failing evaluator = %+v, want nil", issues)
+	}
+}
+
+func TestLoadRegoBundleRegistersOneRulePerFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"no-panic.rego", "naming.rego", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("package nada\n"), 0644); err != nil {
+			t.Fatalf("WriteFile(%s): %v", name, err)
+		}
+	}
+
+	engine := &Engine{disabled: make(map[string]bool)}
+	if err := engine.LoadRegoBundle(dir); err != nil {
+		t.Fatalf("LoadRegoBundle() error = %v", err)
+	}
+
+	ids := make(map[string]bool)
+	for _, rule := range engine.GetRules() {
+		ids[rule.ID()] = true
+	}
+	if len(ids) != 2 || !ids["no-panic"] || !ids["naming"] {
+		t.Errorf("LoadRegoBundle() registered rules %v, want exactly {no-panic, naming}", ids)
+	}
+}
+
+func TestLoadRegoBundleMissingDirIsNotAnError(t *testing.T) {
+	engine := &Engine{disabled: make(map[string]bool)}
+	if err := engine.LoadRegoBundle(filepath.Join(t.TempDir(), "does-not-exist")); err != nil {
+		t.Errorf("LoadRegoBundle() on a missing dir error = %v, want nil", err)
+	}
+	if len(engine.GetRules()) != 0 {
+		t.Errorf("LoadRegoBundle() on a missing dir registered %d rules, want 0", len(engine.GetRules()))
+	}
+}
+
+func TestLoadRegoModuleRegistersRule(t *testing.T) {
+	engine := &Engine{disabled: make(map[string]bool)}
+	if err := engine.LoadRegoModule("no-panic", "package nada\n\ndeny[msg] { false }\n"); err != nil {
+		t.Fatalf("LoadRegoModule() error = %v", err)
+	}
+
+	rules := engine.GetRules()
+	if len(rules) != 1 || rules[0].ID() != "no-panic" {
+		t.Fatalf("LoadRegoModule() registered rules = %+v, want exactly one with ID no-panic", rules)
+	}
+}
+
+func TestEngineRegisterExternal(t *testing.T) {
+	engine := &Engine{disabled: make(map[string]bool)}
+
+	if got := engine.GetExternalLinters(); got != nil {
+		t.Fatalf("GetExternalLinters() on a fresh engine = %+v, want nil", got)
+	}
+
+	tool := ExternalLinter{Name: "staticcheck", Bin: "staticcheck"}
+	engine.RegisterExternal(tool)
+
+	got := engine.GetExternalLinters()
+	if len(got) != 1 || got[0].Name != "staticcheck" {
+		t.Fatalf("GetExternalLinters() = %+v, want exactly one linter named staticcheck", got)
+	}
+}
+
 // Benchmark tests
 func BenchmarkEngineAnalyzeFile(b *testing.B) {
 	engine := NewEngine()
@@ -428,3 +1029,319 @@ func main() {
 		_ = engine.AnalyzeFile("test.go", node, code, fset)
 	}
 }
+
+// Test ErrorHandlingRule resolves qualified calls against a file's imports
+// and only flags results that are syntactically discarded.
+func TestErrorHandlingRuleDiscardedCall(t *testing.T) {
+	rule := NewErrorHandlingRule()
+
+	code := `package main
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+func handled() {
+	file, err := os.Open("a.txt")
+	if err != nil {
+		return
+	}
+	defer file.Close()
+}
+
+func discarded() {
+	file, _ := os.Open("b.txt")
+	defer file.Close()
+
+	data, _ := ioutil.ReadAll(file)
+	_ = data
+}`
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	issues := rule.Check("test.go", node, code, fset)
+
+	var sawMissingErrorHandling, sawDeprecated int
+	for _, issue := range issues {
+		switch issue.Rule {
+		case "missing_error_handling":
+			sawMissingErrorHandling++
+		case "deprecated_function":
+			sawDeprecated++
+		}
+	}
+
+	if sawMissingErrorHandling != 1 {
+		t.Errorf("missing_error_handling issues = %d, want 1 (only the discarded os.Open in discarded())", sawMissingErrorHandling)
+	}
+	if sawDeprecated != 1 {
+		t.Errorf("deprecated_function issues = %d, want 1 (io/ioutil.ReadAll)", sawDeprecated)
+	}
+}
+
+// Test SecurityRule's taint tracker: parameterized placeholders and
+// recognized sanitizers don't trigger sql_injection, but a same-file helper
+// that threads a tainted parameter into its return value is still caught
+// via the memoized function summary.
+func TestSecurityRuleTaintSanitizersAndInterprocedural(t *testing.T) {
+	rule := NewSecurityRule()
+
+	code := `package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+)
+
+func buildQuery(id string) string {
+	return "SELECT * FROM users WHERE id = " + id
+}
+
+func safe(db *sql.DB, r *http.Request) {
+	userInput := r.FormValue("id")
+	db.Query("SELECT * FROM users WHERE id = ?", userInput)
+	db.Query("SELECT * FROM users WHERE id = " + strconv.Quote(userInput))
+}
+
+func unsafe(db *sql.DB, r *http.Request) {
+	userInput := r.FormValue("id")
+	db.Query(buildQuery(userInput))
+}`
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	issues := rule.Check("test.go", node, code, fset)
+
+	sqlIssues := 0
+	for _, issue := range issues {
+		if issue.Rule == "sql_injection" {
+			sqlIssues++
+			if issue.Line != 21 {
+				t.Errorf("sql_injection issue at line %d, want 21 (the call in unsafe())", issue.Line)
+			}
+		}
+	}
+	if sqlIssues != 1 {
+		t.Errorf("sql_injection issues = %d, want 1 (only unsafe()'s db.Query(buildQuery(userInput)))", sqlIssues)
+	}
+}
+
+// Test StyleRule's four package-level checks together: no package comment,
+// an exported func with no doc comment, a capitalized error string, and two
+// methods of the same type disagreeing on their receiver name.
+func TestStyleRule(t *testing.T) {
+	rule := NewStyleRule()
+
+	codeA := `package widgets
+
+type Widget struct {
+	Name string
+}
+
+// Render draws the widget.
+func (w *Widget) Render() {
+}
+
+func (widget *Widget) Reset() {
+}
+
+func DoThing() {
+}`
+
+	codeB := `package widgets
+
+import "errors"
+
+func check() error {
+	return errors.New("Something went wrong")
+}`
+
+	fset := token.NewFileSet()
+	nodeA, err := parser.ParseFile(fset, "widget.go", codeA, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+	nodeB, err := parser.ParseFile(fset, "check.go", codeB, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+
+	issues := rule.CheckPackage("widgets", []*ast.File{nodeA, nodeB}, fset)
+
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		counts[issue.Rule]++
+	}
+
+	if counts["missing_package_comment"] != 1 {
+		t.Errorf("missing_package_comment issues = %d, want 1", counts["missing_package_comment"])
+	}
+	if counts["doc_comment_mismatch"] != 1 {
+		t.Errorf("doc_comment_mismatch issues = %d, want 1 (DoThing has no doc comment)", counts["doc_comment_mismatch"])
+	}
+	if counts["error_string_format"] != 1 {
+		t.Errorf("error_string_format issues = %d, want 1 (capitalized error string)", counts["error_string_format"])
+	}
+	if counts["receiver_name_style"] != 2 {
+		t.Errorf("receiver_name_style issues = %d, want 2 (Render's \"w\" and Reset's \"widget\" disagree)", counts["receiver_name_style"])
+	}
+}
+
+// Test NamingRule's golint-style initialism handling: words matching
+// commonInitialisms get capitalized in the suggested rename, words that
+// don't are left alone.
+func TestNamingRuleAbbreviationConvention(t *testing.T) {
+	rule := NewNamingRule()
+
+	tests := []struct {
+		name       string
+		identifier string
+		wantRule   bool
+		suggestion string
+	}{
+		{name: "http and url both improper", identifier: "httpGetURL", wantRule: true, suggestion: "HTTPGetURL"},
+		{name: "json improper", identifier: "jsonData", wantRule: true, suggestion: "JSONData"},
+		{name: "already correct", identifier: "serveHTTP", wantRule: false},
+		{name: "no initialism", identifier: "doWork", wantRule: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := "package main\nfunc " + tt.identifier + "() {}"
+			fset := token.NewFileSet()
+			node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse test code: %v", err)
+			}
+
+			issues := rule.Check("test.go", node, code, fset)
+
+			var found *types.Issue
+			for i := range issues {
+				if issues[i].Rule == "abbreviation_convention" {
+					found = &issues[i]
+					break
+				}
+			}
+
+			if tt.wantRule && found == nil {
+				t.Fatalf("no abbreviation_convention issue for %q, want one suggesting %q", tt.identifier, tt.suggestion)
+			}
+			if !tt.wantRule && found != nil {
+				t.Fatalf("unexpected abbreviation_convention issue for %q: %+v", tt.identifier, found)
+			}
+			if tt.wantRule && found.Suggestion != tt.suggestion {
+				t.Errorf("Suggestion = %q, want %q", found.Suggestion, tt.suggestion)
+			}
+		})
+	}
+}
+
+// Test that govulncheck's NDJSON stream resolves a finding's OSV ID back to
+// its summary and to the first trace frame carrying a source position.
+func TestParseGovulncheckOutput(t *testing.T) {
+	output := `{"osv":{"id":"GO-2024-0001","summary":"Example vulnerability in badmodule"}}
+{"finding":{"osv":"GO-2024-0001","trace":[{"position":{"filename":"main.go","line":10,"column":2}}]}}
+`
+
+	issues, err := parseGovulncheckOutput([]byte(output))
+	if err != nil {
+		t.Fatalf("parseGovulncheckOutput() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("len(issues) = %d, want 1", len(issues))
+	}
+
+	issue := issues[0]
+	if issue.Rule != "GO-2024-0001" {
+		t.Errorf("Rule = %q, want GO-2024-0001", issue.Rule)
+	}
+	if issue.Message != "Example vulnerability in badmodule" {
+		t.Errorf("Message = %q, want the OSV summary", issue.Message)
+	}
+	if issue.File != "main.go" || issue.Line != 10 {
+		t.Errorf("File/Line = %s:%d, want main.go:10", issue.File, issue.Line)
+	}
+	if issue.Type != types.TypeVulnerability {
+		t.Errorf("Type = %q, want %q", issue.Type, types.TypeVulnerability)
+	}
+}
+
+// ExternalLinter must satisfy ToolAdapter, and Available() must correctly
+// distinguish an installed binary ("go", used to run this very test suite)
+// from one that doesn't exist.
+func TestExternalLinterIsToolAdapter(t *testing.T) {
+	var _ ToolAdapter = ExternalLinter{}
+
+	installed := ExternalLinter{Name: "govet", Bin: "go"}
+	if !installed.Available() {
+		t.Error("Available() = false for \"go\", want true")
+	}
+
+	missing := ExternalLinter{Name: "nonexistent", Bin: "nada-does-not-exist-binary"}
+	if missing.Available() {
+		t.Error("Available() = true for a nonexistent binary, want false")
+	}
+}
+
+// TestUnusedExportRule builds a two-package module on disk - widgets,
+// exporting both Used (referenced from main) and Unused (referenced by
+// nobody) - and checks that only Unused is flagged.
+func TestUnusedExportRule(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(rel, content string) {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll(%s): %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%s): %v", rel, err)
+		}
+	}
+
+	writeFile("go.mod", "module example.com/app\n\ngo 1.21\n")
+	writeFile("widgets/widgets.go", `package widgets
+
+// Used is called from main.
+func Used() int { return 1 }
+
+// Unused is never called by anything in this module.
+func Unused() int { return 2 }
+`)
+	writeFile("main.go", `package main
+
+import "example.com/app/widgets"
+
+func main() {
+	widgets.Used()
+}
+`)
+
+	prog, err := typecheck.Load(dir)
+	if err != nil {
+		t.Fatalf("typecheck.Load() error = %v", err)
+	}
+
+	rule := NewUnusedExportRule()
+	issues := rule.CheckProgram(prog)
+
+	if len(issues) != 1 {
+		t.Fatalf("CheckProgram() = %d issues, want 1: %+v", len(issues), issues)
+	}
+	if !strings.Contains(issues[0].Message, `"Unused"`) {
+		t.Errorf("Message = %q, want it to name Unused", issues[0].Message)
+	}
+	if issues[0].Rule != "unused_export" {
+		t.Errorf("Rule = %q, want unused_export", issues[0].Rule)
+	}
+}
@@ -4,10 +4,30 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"strconv"
 
 	"github.com/chaksack/nada/internal/types"
 )
 
+// ErrorHandlingRule flags deprecated standard-library functions and calls
+// whose error return appears to be silently discarded.
+//
+// The ideal version of this rule resolves selector expressions and call
+// result types via go/types, which is why it used to be written against
+// golang.org/x/tools/go/analysis: an Analyzer driven by packages.Load with
+// NeedTypes|NeedTypesInfo would let it match calls by fully-qualified import
+// path and know for certain whether a result type contains error. That
+// tooling isn't importable in this tree without a go.mod (see the
+// AnalyzerAdapter doc comment in external.go for the established
+// rationale), so this rule instead resolves selector calls itself from each
+// file's own import declarations and treats the result as discarded when
+// it's syntactically dropped - a bare ExprStmt or an assignment to "_".
+// That's strictly AST-level, so it can't see through aliasing a package
+// import to a local variable or follow method sets across files, but it
+// fixes the concrete bug this rule shipped with: matching call.Fun against
+// a *ast.Ident can never succeed for a qualified call like os.Open, since
+// that parses as a *ast.SelectorExpr, so every qualified check below was
+// previously dead code that also ignored call sites handling the error.
 type ErrorHandlingRule struct{}
 
 func NewErrorHandlingRule() *ErrorHandlingRule {
@@ -23,15 +43,28 @@ func (r *ErrorHandlingRule) Description() string {
 func (r *ErrorHandlingRule) Check(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue {
 	var issues []types.Issue
 
+	aliases := importAliases(node)
+	discarded := discardedCalls(node)
+
 	ast.Inspect(node, func(n ast.Node) bool {
 		if n == nil {
 			return false
 		}
 
-		if call, ok := n.(*ast.CallExpr); ok {
-			pos := fset.Position(call.Pos())
-			issues = append(issues, r.checkDeprecatedFunctions(file, call, pos)...)
-			issues = append(issues, r.checkErrorHandling(file, call, pos)...)
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		qualified, ok := qualifiedCallName(call, aliases)
+		if !ok {
+			return true
+		}
+
+		pos := fset.Position(call.Pos())
+		issues = append(issues, r.checkDeprecatedFunctions(file, qualified, pos)...)
+		if discarded[call] {
+			issues = append(issues, r.checkErrorHandling(file, qualified, pos)...)
 		}
 
 		return true
@@ -40,59 +73,152 @@ func (r *ErrorHandlingRule) Check(file string, node ast.Node, content string, fs
 	return issues
 }
 
-func (r *ErrorHandlingRule) checkDeprecatedFunctions(file string, call *ast.CallExpr, pos token.Position) []types.Issue {
-	var issues []types.Issue
+// importAliases maps each local identifier a file uses for an import (its
+// explicit alias, or the package name implied by the import path) to the
+// full import path, so a selector call like ioutil.ReadFile can be resolved
+// to "io/ioutil.ReadFile" the same way a type-checked pass would resolve it
+// via types.Info, without actually needing type information.
+func importAliases(node ast.Node) map[string]string {
+	aliases := make(map[string]string)
+
+	f, ok := node.(*ast.File)
+	if !ok {
+		return aliases
+	}
 
-	if ident, ok := call.Fun.(*ast.Ident); ok {
-		deprecatedFuncs := map[string]string{
-			"ioutil.ReadFile":  "Use os.ReadFile instead",
-			"ioutil.WriteFile": "Use os.WriteFile instead",
-			"ioutil.ReadAll":   "Use io.ReadAll instead",
+	for _, imp := range f.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
 		}
 
-		for deprecated, suggestion := range deprecatedFuncs {
-			if ident.Name == deprecated {
-				issues = append(issues, types.Issue{
-					Type:        types.TypeCodeSmell,
-					Severity:    types.SeverityMedium,
-					File:        file,
-					Line:        pos.Line,
-					Column:      pos.Column,
-					Rule:        "deprecated_function",
-					Message:     "Deprecated function usage",
-					Description: fmt.Sprintf("Function '%s' is deprecated. %s", deprecated, suggestion),
-					Impact:      types.IssueImpact{EffortMinutes: 2},
-				})
-			}
+		local := path
+		if idx := lastSlash(path); idx >= 0 {
+			local = path[idx+1:]
+		}
+		if imp.Name != nil {
+			local = imp.Name.Name
 		}
+		aliases[local] = path
 	}
 
-	return issues
+	return aliases
 }
 
-func (r *ErrorHandlingRule) checkErrorHandling(file string, call *ast.CallExpr, pos token.Position) []types.Issue {
-	var issues []types.Issue
+func lastSlash(s string) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == '/' {
+			return i
+		}
+	}
+	return -1
+}
+
+// qualifiedCallName resolves a call's callee to "<import path>.<Func>" using
+// the file's own import aliases, returning false when the callee isn't a
+// package-qualified call this rule can resolve (a local function, a method
+// call on a non-package value, or a package this file doesn't import).
+func qualifiedCallName(call *ast.CallExpr, aliases map[string]string) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
 
-	// This is a simplified check for demonstration
-	if ident, ok := call.Fun.(*ast.Ident); ok {
-		riskyFuncs := []string{"os.Open", "json.Marshal", "strconv.Atoi", "http.Get"}
-
-		for _, risky := range riskyFuncs {
-			if ident.Name == risky {
-				issues = append(issues, types.Issue{
-					Type:        types.TypeBug,
-					Severity:    types.SeverityMedium,
-					File:        file,
-					Line:        pos.Line,
-					Column:      pos.Column,
-					Rule:        "missing_error_handling",
-					Message:     "Potential missing error handling",
-					Description: fmt.Sprintf("Function '%s' may return an error that should be handled", risky),
-					Impact:      types.IssueImpact{EffortMinutes: 3},
-				})
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+
+	path, ok := aliases[pkgIdent.Name]
+	if !ok {
+		return "", false
+	}
+
+	return path + "." + sel.Sel.Name, true
+}
+
+// discardedCalls reports, for every CallExpr in node, whether its error
+// result is syntactically dropped: the call is its own expression
+// statement, or the last value it returns into an assignment - the error,
+// by Go convention - is bound to "_". This is the AST-only stand-in for
+// "the call's error result isn't checked"; it doesn't require every LHS to
+// be "_", since the ordinary discard pattern keeps the non-error result
+// (file, _ := os.Open(name)) rather than throwing all of them away.
+func discardedCalls(node ast.Node) map[*ast.CallExpr]bool {
+	discarded := make(map[*ast.CallExpr]bool)
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.ExprStmt:
+			if call, ok := stmt.X.(*ast.CallExpr); ok {
+				discarded[call] = true
+			}
+		case *ast.AssignStmt:
+			if len(stmt.Rhs) != 1 || len(stmt.Lhs) == 0 {
+				return true
+			}
+			call, ok := stmt.Rhs[0].(*ast.CallExpr)
+			if !ok {
+				return true
 			}
+			last, ok := stmt.Lhs[len(stmt.Lhs)-1].(*ast.Ident)
+			if !ok || last.Name != "_" {
+				return true
+			}
+			discarded[call] = true
 		}
+		return true
+	})
+
+	return discarded
+}
+
+func (r *ErrorHandlingRule) checkDeprecatedFunctions(file, qualified string, pos token.Position) []types.Issue {
+	deprecatedFuncs := map[string]string{
+		"io/ioutil.ReadFile":  "Use os.ReadFile instead",
+		"io/ioutil.WriteFile": "Use os.WriteFile instead",
+		"io/ioutil.ReadAll":   "Use io.ReadAll instead",
 	}
 
-	return issues
+	suggestion, ok := deprecatedFuncs[qualified]
+	if !ok {
+		return nil
+	}
+
+	return []types.Issue{{
+		Type:        types.TypeCodeSmell,
+		Severity:    types.SeverityMedium,
+		File:        file,
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Rule:        "deprecated_function",
+		Message:     "Deprecated function usage",
+		Description: fmt.Sprintf("Function '%s' is deprecated. %s", qualified, suggestion),
+		Impact:      types.IssueImpact{EffortMinutes: 2},
+	}}
+}
+
+func (r *ErrorHandlingRule) checkErrorHandling(file, qualified string, pos token.Position) []types.Issue {
+	riskyFuncs := map[string]bool{
+		"os.Open":               true,
+		"encoding/json.Marshal": true,
+		"strconv.Atoi":          true,
+		"net/http.Get":          true,
+	}
+
+	if !riskyFuncs[qualified] {
+		return nil
+	}
+
+	return []types.Issue{{
+		Type:        types.TypeBug,
+		Severity:    types.SeverityMedium,
+		File:        file,
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Rule:        "missing_error_handling",
+		Message:     "Potential missing error handling",
+		Description: fmt.Sprintf("Function '%s' returns an error that isn't checked at this call site", qualified),
+		Impact:      types.IssueImpact{EffortMinutes: 3},
+	}}
 }
@@ -0,0 +1,47 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// ExternalCheck is the function signature an external analyzer adapts to:
+// given a parsed file it returns the issues it found, independent of any
+// other rule's state.
+type ExternalCheck func(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue
+
+// AnalyzerAdapter lets a third-party analyzer be registered on an Engine
+// alongside the hand-rolled rules by satisfying the Rule interface.
+//
+// A real golang.org/x/tools/go/analysis analyzer (staticcheck, gosec,
+// gocyclo, ...) needs type-checked packages loaded via packages.Load rather
+// than the single-file parser.ParseFile the rest of this engine uses, and
+// pulling in those modules isn't possible in this tree without a go.mod.
+// The adapter boundary is deliberately this ExternalCheck function so that
+// wiring a real analyzer in later is a matter of loading the package once
+// per run, translating its analysis.Diagnostic results into types.Issue,
+// and registering the result here - no changes to Engine or the Rule
+// interface required. NewIneffectualAssignmentRule below is one adapter
+// implemented today, since that check doesn't require type information.
+type AnalyzerAdapter struct {
+	id          string
+	name        string
+	description string
+	check       ExternalCheck
+}
+
+// NewAnalyzerAdapter builds a Rule around an external analyzer identified by
+// id/name/description, running check against every analyzed file.
+func NewAnalyzerAdapter(id, name, description string, check ExternalCheck) *AnalyzerAdapter {
+	return &AnalyzerAdapter{id: id, name: name, description: description, check: check}
+}
+
+func (a *AnalyzerAdapter) ID() string          { return a.id }
+func (a *AnalyzerAdapter) Name() string        { return a.name }
+func (a *AnalyzerAdapter) Description() string { return a.description }
+
+func (a *AnalyzerAdapter) Check(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue {
+	return a.check(file, node, content, fset)
+}
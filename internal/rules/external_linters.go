@@ -0,0 +1,538 @@
+package rules
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// ToolAdapter is the interface a third-party linter satisfies to be run by
+// LinterAggregator: whether its binary is on $PATH, the *exec.Cmd that
+// invokes it against a project, and how to turn its stdout into issues.
+// ExternalLinter is the one concrete implementation - every built-in tool is
+// a data-driven instance of it rather than its own type, since all of them
+// reduce to "run this command, parse this output shape".
+type ToolAdapter interface {
+	Available() bool
+	Command(paths []string) *exec.Cmd
+	Parse(stdout []byte) ([]types.Issue, error)
+}
+
+// ExternalLinter shells out to a third-party linter binary and parses its
+// output into types.Issue values, the same adapter boundary AnalyzerAdapter
+// uses for in-process analyzers - except these run once per project (via
+// `go vet`, `staticcheck`, etc.'s own package loading) rather than once per
+// file, since none of them expose a single-file check the way this engine's
+// parser.ParseFile-based Rule.Check does.
+type ExternalLinter struct {
+	// Name identifies the linter in AnalysisOptions.Linters and config's
+	// "linters:" list.
+	Name string
+	// Bin is the binary to run; Args are passed after projectPath is
+	// appended (or, for linters that want it positioned differently,
+	// Args itself should include "./...").
+	Bin  string
+	Args []string
+	// Parser turns the command's stdout into issues. A linter that exits
+	// non-zero on findings (the norm for lint tools) is not itself an
+	// error - only a missing binary or unparseable output is.
+	Parser func(output []byte) ([]types.Issue, error)
+}
+
+var _ ToolAdapter = ExternalLinter{}
+
+// Available reports whether l.Bin is installed on $PATH.
+func (l ExternalLinter) Available() bool {
+	_, err := exec.LookPath(l.Bin)
+	return err == nil
+}
+
+// Command builds the *exec.Cmd that runs l against paths. None of the
+// built-in linters take an explicit file list - they scan whole packages
+// via "./..." baked into Args - so paths is a single-element slice holding
+// the project root, used only as the command's working directory.
+func (l ExternalLinter) Command(paths []string) *exec.Cmd {
+	cmd := exec.Command(l.Bin, l.Args...)
+	if len(paths) > 0 {
+		cmd.Dir = paths[0]
+	}
+	return cmd
+}
+
+// Parse delegates to l.Parser.
+func (l ExternalLinter) Parse(stdout []byte) ([]types.Issue, error) {
+	return l.Parser(stdout)
+}
+
+// DefaultExternalLinters is the built-in registry of linters --linters can
+// name: go vet's own JSON-free text output, the JSON/NDJSON output modes of
+// staticcheck, gosec, errcheck, and revive, and govulncheck's CVE scan.
+func DefaultExternalLinters() []ExternalLinter {
+	return []ExternalLinter{
+		{Name: "govet", Bin: "go", Args: []string{"vet", "./..."}, Parser: parseGoVetOutput},
+		{Name: "staticcheck", Bin: "staticcheck", Args: []string{"-f", "json", "./..."}, Parser: parseStaticcheckOutput},
+		{Name: "gosec", Bin: "gosec", Args: []string{"-fmt=json", "-quiet", "./..."}, Parser: parseGosecOutput},
+		{Name: "errcheck", Bin: "errcheck", Args: []string{"./..."}, Parser: parseErrcheckOutput},
+		{Name: "revive", Bin: "revive", Args: []string{"-formatter", "json", "./..."}, Parser: parseReviveOutput},
+		{Name: "govulncheck", Bin: "govulncheck", Args: []string{"-json", "./..."}, Parser: parseGovulncheckOutput},
+	}
+}
+
+// defaultToolTimeout bounds how long any single external linter may run
+// before LinterAggregator.Run kills it and moves on - a hung or
+// pathologically slow tool shouldn't stall the whole analysis.
+const defaultToolTimeout = 2 * time.Minute
+
+// LinterAggregator runs a set of ExternalLinters concurrently over one
+// project and merges their findings with the AST-based Engine's, the same
+// "many tools, one report" role golangci-lint plays over its own linter set.
+type LinterAggregator struct {
+	Linters []ExternalLinter
+	// Timeout bounds each linter's run. Zero means defaultToolTimeout.
+	Timeout time.Duration
+}
+
+// NewLinterAggregator builds an aggregator running only the named linters
+// (matched against ExternalLinter.Name), in the order DefaultExternalLinters
+// declares them. An unknown name is ignored rather than erroring, since a
+// linter not installed locally is the expected steady state for most of
+// this list - Run already tolerates a missing binary the same way.
+func NewLinterAggregator(names []string) *LinterAggregator {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	agg := &LinterAggregator{Timeout: defaultToolTimeout}
+	for _, linter := range DefaultExternalLinters() {
+		if wanted[linter.Name] {
+			agg.Linters = append(agg.Linters, linter)
+		}
+	}
+	return agg
+}
+
+// Run executes every configured linter against projectPath concurrently,
+// bounded by runtime.NumCPU(), and returns their combined, deduplicated
+// issues. A linter whose binary isn't installed, whose run exceeds
+// a.Timeout, or whose output fails to parse, is skipped rather than failing
+// the run - consistent with how vulndb.Source and ignore.Load treat a
+// missing optional input as "nothing to add" rather than an error.
+func (a *LinterAggregator) Run(projectPath string) ([]types.Issue, error) {
+	if len(a.Linters) == 0 {
+		return nil, nil
+	}
+
+	timeout := a.Timeout
+	if timeout <= 0 {
+		timeout = defaultToolTimeout
+	}
+
+	workers := runtime.NumCPU()
+	if workers > len(a.Linters) {
+		workers = len(a.Linters)
+	}
+
+	linterCh := make(chan ExternalLinter)
+	resultCh := make(chan []types.Issue)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for linter := range linterCh {
+				if !linter.Available() {
+					continue
+				}
+				issues, err := runToolAdapter(linter, []string{projectPath}, timeout)
+				if err != nil {
+					continue
+				}
+				resultCh <- issues
+			}
+		}()
+	}
+
+	go func() {
+		for _, linter := range a.Linters {
+			linterCh <- linter
+		}
+		close(linterCh)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	var all []types.Issue
+	for issues := range resultCh {
+		all = append(all, issues...)
+	}
+
+	return dedupeIssues(all), nil
+}
+
+// runToolAdapter runs one ToolAdapter's command, bounded by timeout, and
+// hands its stdout to Parse. Most of these tools exit non-zero when they
+// find anything, so a non-zero exit is only treated as a real failure when
+// stdout is empty too - otherwise Parse gets a chance to parse whatever was
+// produced. Every resulting issue is tagged with the originating linter's
+// name in both Category (the same grouping AnalyzeFile uses for AST rules)
+// and Source (so a reporter can tell a native finding from an external
+// tool's without depending on Category's rule-ID convention).
+func runToolAdapter(linter ExternalLinter, paths []string, timeout time.Duration) ([]types.Issue, error) {
+	cmd := linter.Command(paths)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%s: %w", linter.Name, err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var runErr error
+	select {
+	case runErr = <-done:
+	case <-time.After(timeout):
+		_ = cmd.Process.Kill()
+		<-done
+		return nil, fmt.Errorf("%s: timed out after %s", linter.Name, timeout)
+	}
+
+	if runErr != nil && stdout.Len() == 0 {
+		return nil, fmt.Errorf("%s: %w", linter.Name, runErr)
+	}
+
+	issues, err := linter.Parse(stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: parsing output: %w", linter.Name, err)
+	}
+
+	for i := range issues {
+		if issues[i].Category == "" {
+			issues[i].Category = linter.Name
+		}
+		issues[i].Source = linter.Name
+	}
+
+	return issues, nil
+}
+
+// dedupeIssues drops duplicate (File, Line, Rule) triples, keeping the
+// first occurrence, so the same finding surfaced by two overlapping linters
+// (or an external linter re-detecting something the AST rules already
+// flagged) isn't double-counted in IssuesSummary and quality gates.
+func dedupeIssues(issues []types.Issue) []types.Issue {
+	seen := make(map[string]bool, len(issues))
+	deduped := make([]types.Issue, 0, len(issues))
+	for _, issue := range issues {
+		key := fmt.Sprintf("%s|%d|%s", issue.File, issue.Line, issue.Rule)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, issue)
+	}
+	return deduped
+}
+
+// goVetLinePattern matches `go vet`'s and errcheck's shared plain-text
+// diagnostic shape: "path/to/file.go:12:3: message".
+var goVetLinePattern = regexp.MustCompile(`^(.+\.go):(\d+):(\d+):\s*(.+)$`)
+
+// parseGoVetOutput parses `go vet`'s plain-text stderr-on-stdout diagnostics
+// (one per line) into bug issues, since a vet finding is almost always a
+// genuine defect (nil dereference, unreachable code, bad Printf verb, ...)
+// rather than a style nit.
+func parseGoVetOutput(output []byte) ([]types.Issue, error) {
+	return parseLineDiagnostics(output, "govet", types.TypeBug, types.SeverityHigh)
+}
+
+// parseErrcheckOutput parses errcheck's plain-text "file:line:col:\tmessage"
+// diagnostics - an unchecked error is a bug, not a style preference.
+func parseErrcheckOutput(output []byte) ([]types.Issue, error) {
+	return parseLineDiagnostics(output, "errcheck", types.TypeBug, types.SeverityMedium)
+}
+
+// parseLineDiagnostics is shared by parseGoVetOutput and parseErrcheckOutput,
+// both of which emit one "file:line:col: message" diagnostic per line.
+func parseLineDiagnostics(output []byte, rule, issueType, severity string) ([]types.Issue, error) {
+	var issues []types.Issue
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		matches := goVetLinePattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		lineNum, _ := strconv.Atoi(matches[2])
+		col, _ := strconv.Atoi(matches[3])
+
+		issues = append(issues, types.Issue{
+			Type:     issueType,
+			Severity: severity,
+			File:     matches[1],
+			Line:     lineNum,
+			Column:   col,
+			Rule:     rule,
+			Message:  strings.TrimSpace(matches[4]),
+		})
+	}
+
+	return issues, scanner.Err()
+}
+
+// staticcheckFinding is one line of `staticcheck -f json`'s NDJSON output.
+type staticcheckFinding struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Location struct {
+		File   string `json:"file"`
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+	} `json:"location"`
+	Message string `json:"message"`
+}
+
+// parseStaticcheckOutput parses staticcheck's NDJSON (one JSON object per
+// line, not a single array).
+func parseStaticcheckOutput(output []byte) ([]types.Issue, error) {
+	var issues []types.Issue
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var finding staticcheckFinding
+		if err := json.Unmarshal(line, &finding); err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, types.Issue{
+			Type:     staticcheckIssueType(finding.Code),
+			Severity: staticcheckSeverity(finding.Severity),
+			File:     finding.Location.File,
+			Line:     finding.Location.Line,
+			Column:   finding.Location.Column,
+			Rule:     finding.Code,
+			Message:  finding.Message,
+		})
+	}
+
+	return issues, scanner.Err()
+}
+
+// staticcheckIssueType maps a staticcheck check ID to this module's
+// taxonomy: "SA" (staticcheck analysis) checks are almost all genuine bugs,
+// everything else (ST style, S1 simplification, U unused) is a code smell.
+func staticcheckIssueType(code string) string {
+	if strings.HasPrefix(code, "SA") {
+		return types.TypeBug
+	}
+	return types.TypeCodeSmell
+}
+
+func staticcheckSeverity(severity string) string {
+	switch severity {
+	case "error":
+		return types.SeverityHigh
+	case "warning":
+		return types.SeverityMedium
+	default:
+		return types.SeverityLow
+	}
+}
+
+// gosecReport is the top-level shape of `gosec -fmt=json`'s output.
+type gosecReport struct {
+	Issues []struct {
+		RuleID   string `json:"rule_id"`
+		Details  string `json:"details"`
+		Severity string `json:"severity"`
+		File     string `json:"file"`
+		Line     string `json:"line"`
+		Column   string `json:"column"`
+	} `json:"Issues"`
+}
+
+// parseGosecOutput parses gosec's JSON report into vulnerability issues,
+// since every rule gosec ships (hardcoded credentials, SQL injection,
+// weak crypto, ...) is a security finding rather than a style one.
+func parseGosecOutput(output []byte) ([]types.Issue, error) {
+	var report gosecReport
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, err
+	}
+
+	issues := make([]types.Issue, 0, len(report.Issues))
+	for _, finding := range report.Issues {
+		line, _ := strconv.Atoi(finding.Line)
+		col, _ := strconv.Atoi(finding.Column)
+
+		issues = append(issues, types.Issue{
+			Type:     types.TypeVulnerability,
+			Severity: gosecSeverity(finding.Severity),
+			File:     finding.File,
+			Line:     line,
+			Column:   col,
+			Rule:     finding.RuleID,
+			Message:  finding.Details,
+		})
+	}
+
+	return issues, nil
+}
+
+func gosecSeverity(severity string) string {
+	switch strings.ToUpper(severity) {
+	case "HIGH":
+		return types.SeverityHigh
+	case "MEDIUM":
+		return types.SeverityMedium
+	default:
+		return types.SeverityLow
+	}
+}
+
+// reviveFinding is one entry of `revive -formatter json`'s output array.
+type reviveFinding struct {
+	Severity string `json:"severity"`
+	Failure  string `json:"failure"`
+	RuleName string `json:"rule_name"`
+	Position struct {
+		Start struct {
+			Filename string `json:"filename"`
+			Line     int    `json:"line"`
+			Column   int    `json:"column"`
+		} `json:"start"`
+	} `json:"position"`
+}
+
+// parseReviveOutput parses revive's JSON array into code-smell issues -
+// revive is a style/best-practice linter, not a bug or vulnerability finder.
+func parseReviveOutput(output []byte) ([]types.Issue, error) {
+	var findings []reviveFinding
+	if err := json.Unmarshal(output, &findings); err != nil {
+		return nil, err
+	}
+
+	issues := make([]types.Issue, 0, len(findings))
+	for _, finding := range findings {
+		issues = append(issues, types.Issue{
+			Type:     types.TypeCodeSmell,
+			Severity: reviveSeverity(finding.Severity),
+			File:     finding.Position.Start.Filename,
+			Line:     finding.Position.Start.Line,
+			Column:   finding.Position.Start.Column,
+			Rule:     finding.RuleName,
+			Message:  finding.Failure,
+		})
+	}
+
+	return issues, nil
+}
+
+func reviveSeverity(severity string) string {
+	switch severity {
+	case "error":
+		return types.SeverityHigh
+	case "warning":
+		return types.SeverityMedium
+	default:
+		return types.SeverityLow
+	}
+}
+
+// govulncheckMessage is one line of `govulncheck -json`'s NDJSON stream.
+// Only "osv" (a vulnerability's metadata) and "finding" (one call-path frame
+// that reaches a vulnerable symbol) messages matter here; "config" and
+// "progress" messages are ignored.
+type govulncheckMessage struct {
+	OSV *struct {
+		ID      string `json:"id"`
+		Summary string `json:"summary"`
+	} `json:"osv"`
+	Finding *struct {
+		OSV   string `json:"osv"`
+		Trace []struct {
+			Position *struct {
+				Filename string `json:"filename"`
+				Line     int    `json:"line"`
+				Column   int    `json:"column"`
+			} `json:"position"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// parseGovulncheckOutput parses govulncheck's NDJSON into vulnerability
+// issues: every "osv" message's summary is remembered by ID, and every
+// "finding" message becomes one issue located at its trace's first frame
+// that carries a source position (govulncheck's own call stack, innermost
+// frame first).
+func parseGovulncheckOutput(output []byte) ([]types.Issue, error) {
+	summaries := make(map[string]string)
+	var issues []types.Issue
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var msg govulncheckMessage
+		if err := json.Unmarshal(line, &msg); err != nil {
+			return nil, err
+		}
+
+		if msg.OSV != nil {
+			summaries[msg.OSV.ID] = msg.OSV.Summary
+			continue
+		}
+
+		if msg.Finding == nil {
+			continue
+		}
+
+		var file string
+		var ln, col int
+		for _, frame := range msg.Finding.Trace {
+			if frame.Position != nil {
+				file, ln, col = frame.Position.Filename, frame.Position.Line, frame.Position.Column
+				break
+			}
+		}
+
+		issues = append(issues, types.Issue{
+			Type:     types.TypeVulnerability,
+			Severity: types.SeverityHigh,
+			File:     file,
+			Line:     ln,
+			Column:   col,
+			Rule:     msg.Finding.OSV,
+			Message:  summaries[msg.Finding.OSV],
+		})
+	}
+
+	return issues, scanner.Err()
+}
@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// NewIneffectualAssignmentRule builds the AnalyzerAdapter standing in for
+// ineffassign: it flags a local variable assignment whose value is
+// overwritten by another assignment in the same block before it is ever
+// read.
+func NewIneffectualAssignmentRule() *AnalyzerAdapter {
+	return NewAnalyzerAdapter(
+		"ineffassign",
+		"Ineffectual Assignment",
+		"Detects local variable assignments whose value is never read before being overwritten",
+		checkIneffectualAssignment,
+	)
+}
+
+// checkIneffectualAssignment tracks, per block, which identifiers were most
+// recently assigned by a plain = or := statement. A statement reading that
+// identifier (anywhere other than as the target of a further plain
+// assignment) clears it; any other kind of statement conservatively clears
+// everything, since this heuristic doesn't attempt to follow reads through
+// closures, defers, or nested control flow.
+func checkIneffectualAssignment(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+
+		assigned := make(map[string]*ast.AssignStmt)
+
+		for _, stmt := range block.List {
+			assign, ok := stmt.(*ast.AssignStmt)
+			if !ok {
+				assigned = make(map[string]*ast.AssignStmt)
+				continue
+			}
+
+			for _, rhs := range assign.Rhs {
+				ast.Inspect(rhs, func(n ast.Node) bool {
+					if id, ok := n.(*ast.Ident); ok {
+						delete(assigned, id.Name)
+					}
+					return true
+				})
+			}
+
+			for i, lhs := range assign.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok || id.Name == "_" {
+					continue
+				}
+
+				if assign.Tok != token.ASSIGN && assign.Tok != token.DEFINE {
+					// Compound assignments (+=, -=, ...) read the old
+					// value, so they don't count as an overwrite.
+					delete(assigned, id.Name)
+					continue
+				}
+
+				if prev, ok := assigned[id.Name]; ok {
+					pos := fset.Position(prev.Pos())
+					issues = append(issues, types.Issue{
+						Type:        types.TypeCodeSmell,
+						Severity:    types.SeverityLow,
+						File:        file,
+						Line:        pos.Line,
+						Column:      pos.Column,
+						Rule:        "ineffassign",
+						Message:     "Ineffectual assignment to " + id.Name,
+						Description: "This value is overwritten before it is ever read",
+						Impact:      types.IssueImpact{EffortMinutes: 2},
+					})
+				}
+
+				if i < len(assign.Rhs) {
+					assigned[id.Name] = assign
+				} else {
+					delete(assigned, id.Name)
+				}
+			}
+		}
+
+		return true
+	})
+
+	return issues
+}
@@ -4,12 +4,26 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/chaksack/nada/internal/types"
 )
 
+// commonInitialisms mirrors golint's table of identifiers that are
+// conventionally kept all-uppercase in MixedCaps names (e.g. "ServeHTTP",
+// not "ServeHttp") rather than treated as an ordinary word.
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true,
+	"DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "LHS": true,
+	"QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true,
+	"SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true,
+	"TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true,
+	"URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true,
+	"XMPP": true, "XSRF": true, "XSS": true,
+}
+
 // NamingRule checks for proper naming conventions
 type NamingRule struct{}
 
@@ -46,7 +60,7 @@ func (r *NamingRule) Check(file string, node ast.Node, content string, fset *tok
 
 		switch x := n.(type) {
 		case *ast.FuncDecl:
-			issues = append(issues, r.checkFunctionNaming(file, x, pos)...)
+			issues = append(issues, r.checkFunctionNaming(file, x, pos, fset)...)
 		case *ast.GenDecl:
 			issues = append(issues, r.checkVariableNaming(file, x, pos)...)
 		case *ast.TypeSpec:
@@ -60,7 +74,7 @@ func (r *NamingRule) Check(file string, node ast.Node, content string, fset *tok
 }
 
 // checkFunctionNaming validates function naming conventions
-func (r *NamingRule) checkFunctionNaming(file string, fn *ast.FuncDecl, pos token.Position) []types.Issue {
+func (r *NamingRule) checkFunctionNaming(file string, fn *ast.FuncDecl, pos token.Position, fset *token.FileSet) []types.Issue {
 	var issues []types.Issue
 
 	if fn.Name == nil {
@@ -105,7 +119,7 @@ func (r *NamingRule) checkFunctionNaming(file string, fn *ast.FuncDecl, pos toke
 	}
 
 	// Check for common abbreviations that should be uppercase
-	if r.hasImproperAbbreviations(name) {
+	if improper, suggested := r.hasImproperAbbreviations(name); improper {
 		issues = append(issues, types.Issue{
 			Type:        types.TypeCodeSmell,
 			Severity:    types.SeverityLow,
@@ -114,15 +128,23 @@ func (r *NamingRule) checkFunctionNaming(file string, fn *ast.FuncDecl, pos toke
 			Column:      pos.Column,
 			Rule:        "abbreviation_convention",
 			Message:     "Improper abbreviation capitalization",
-			Description: fmt.Sprintf("Function '%s' should capitalize common abbreviations (HTTP, URL, API, etc.)", name),
+			Description: fmt.Sprintf("Function '%s' should capitalize common initialisms; rename to '%s'", name, suggested),
 			Impact:      types.IssueImpact{EffortMinutes: 2},
+			Suggestion:  suggested,
+			Fixes:       []types.SuggestedFix{renameFix(file, fset, fn.Name, suggested)},
 		})
 	}
 
 	return issues
 }
 
-// checkVariableNaming validates variable naming conventions
+// checkVariableNaming validates variable naming conventions. Unlike
+// abbreviation_convention, a short_variable_name issue has no mechanical
+// fix: renameFix needs a suggested replacement name, and there's no
+// reasonable one to derive from a single letter without the surrounding
+// type/usage context a go/types scope lookup would give - guessing one
+// would be fabricating a fix, not offering one, so this issue carries no
+// Fixes.
 func (r *NamingRule) checkVariableNaming(file string, decl *ast.GenDecl, pos token.Position) []types.Issue {
 	var issues []types.Issue
 
@@ -227,24 +249,89 @@ func (r *NamingRule) isValidPascalCase(name string) bool {
 	return true
 }
 
-// hasImproperAbbreviations checks for common abbreviations that should be uppercase
-func (r *NamingRule) hasImproperAbbreviations(name string) bool {
-	improperPatterns := []string{
-		`(?i)\bhttp\b`, `(?i)\burl\b`, `(?i)\bapi\b`, `(?i)\bjson\b`,
-		`(?i)\bxml\b`, `(?i)\bhtml\b`, `(?i)\bid\b`, `(?i)\bsql\b`,
+// splitNameWords breaks an identifier into the words golint's lintName
+// algorithm would: a new word starts at every lower-to-upper transition, and
+// at the last capital of a run of capitals that's followed by a lowercase
+// letter (so "HTTPServer" splits as "HTTP", "Server", not "H", "T", "T",
+// "PServer").
+func splitNameWords(name string) []string {
+	runes := []rune(name)
+	if len(runes) == 0 {
+		return nil
 	}
 
-	for _, pattern := range improperPatterns {
-		if matched, _ := regexp.MatchString(pattern, name); matched {
-			// Check if it's already properly capitalized
-			properPattern := strings.ToUpper(strings.Trim(pattern, `(?i)\b`))
-			if !strings.Contains(name, properPattern) {
-				return true
-			}
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		cur, prev := runes[i], runes[i-1]
+		boundary := unicode.IsUpper(cur) && !unicode.IsUpper(prev)
+		if !boundary && unicode.IsUpper(cur) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			boundary = true
+		}
+		if boundary {
+			words = append(words, string(runes[start:i]))
+			start = i
 		}
 	}
 
-	return false
+	return append(words, string(runes[start:]))
+}
+
+// hasImproperAbbreviations reports whether name contains a word from
+// commonInitialisms that isn't already fully capitalized, and if so returns
+// name rewritten with every such word upper-cased (e.g. "httpGetURL" ->
+// "HTTPGetURL", "jsonData" -> "JSONData"). The first word is exempt when
+// it's an acceptable short name (receiver-style single letters), so a
+// receiver like "id *Thing" isn't flagged.
+func (r *NamingRule) hasImproperAbbreviations(name string) (bool, string) {
+	words := splitNameWords(name)
+	if len(words) == 0 {
+		return false, ""
+	}
+
+	improper := false
+	rewritten := make([]string, len(words))
+	for i, word := range words {
+		if i == 0 && r.isAcceptableShortName(word) {
+			rewritten[i] = word
+			continue
+		}
+
+		upper := strings.ToUpper(word)
+		if commonInitialisms[upper] && word != upper {
+			improper = true
+			rewritten[i] = upper
+			continue
+		}
+
+		rewritten[i] = word
+	}
+
+	if !improper {
+		return false, ""
+	}
+
+	return true, strings.Join(rewritten, "")
+}
+
+// renameFix proposes replacing ident's declaration with newName. It only
+// rewrites the `func` declaration itself, not call sites - Check only ever
+// sees one file's ast.Node and content, not the whole package, so it has no
+// way to find other references to rename them too. That makes this an
+// incomplete rename, which is exactly why it's Unsafe: applying it without
+// --fix=unsafe would leave the file referring to a function that no longer
+// exists under its old name.
+func renameFix(file string, fset *token.FileSet, ident *ast.Ident, newName string) types.SuggestedFix {
+	start := fset.Position(ident.Pos()).Offset
+	end := fset.Position(ident.End()).Offset
+
+	return types.SuggestedFix{
+		Message: fmt.Sprintf("Rename %s to %s (does not update call sites)", ident.Name, newName),
+		Edits: []types.TextEdit{
+			{File: file, Start: start, End: end, NewText: newName},
+		},
+		Unsafe: true,
+	}
 }
 
 // isAcceptableShortName checks if a short variable name is acceptable
@@ -0,0 +1,278 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// PatternRule is a regex-based Rule loaded from an external YAML rule pack
+// rather than compiled into nada, so organizations can add project-specific
+// checks (banned APIs, naming conventions, required headers) without a Go
+// build. It matches line by line against a file's raw content, the same
+// approach StructureRule's checkTodoComments/checkLineLength use, since a
+// rule pack author writes a pattern, not an AST visitor.
+type PatternRule struct {
+	id          string
+	name        string
+	description string
+	severity    string
+	issueType   string
+	pattern     *regexp.Regexp
+	message     string
+}
+
+// NewPatternRule builds a PatternRule matching pattern against each line of
+// a file's content, reporting message (or pattern's own text if message is
+// empty) at severity/issueType for every match.
+func NewPatternRule(id, name, description, severity, issueType, pattern, message string) (*PatternRule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: invalid pattern %q: %w", id, pattern, err)
+	}
+
+	if severity == "" {
+		severity = types.SeverityMedium
+	}
+	if issueType == "" {
+		issueType = types.TypeCodeSmell
+	}
+	if message == "" {
+		message = "matched pattern: " + pattern
+	}
+
+	return &PatternRule{
+		id:          id,
+		name:        name,
+		description: description,
+		severity:    severity,
+		issueType:   issueType,
+		pattern:     re,
+		message:     message,
+	}, nil
+}
+
+func (r *PatternRule) ID() string          { return r.id }
+func (r *PatternRule) Name() string        { return r.name }
+func (r *PatternRule) Description() string { return r.description }
+
+// Check implements Rule by scanning content line by line for r.pattern,
+// mirroring StructureRule's line-oriented checks above since the AST isn't
+// useful for a freeform pattern rule.
+func (r *PatternRule) Check(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	for i, line := range strings.Split(content, "\n") {
+		if loc := r.pattern.FindStringIndex(line); loc != nil {
+			issues = append(issues, types.Issue{
+				Type:        r.issueType,
+				Severity:    r.severity,
+				File:        file,
+				Line:        i + 1,
+				Column:      loc[0] + 1,
+				Rule:        r.id,
+				Message:     r.message,
+				Description: r.description,
+			})
+		}
+	}
+
+	return issues
+}
+
+// rulePackEntry is one "- id: ... / pattern: ..." item parsed out of a rule
+// pack YAML file's top-level "rules" list.
+type rulePackEntry struct {
+	id          string
+	name        string
+	description string
+	severity    string
+	issueType   string
+	pattern     string
+	message     string
+}
+
+// LoadRulePack reads the YAML rule pack at path and builds a PatternRule for
+// each entry in its "rules" list.
+func LoadRulePack(path string) ([]*PatternRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := parseRulePackYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	rules := make([]*PatternRule, 0, len(entries))
+	for _, e := range entries {
+		rule, err := NewPatternRule(e.id, e.name, e.description, e.severity, e.issueType, e.pattern, e.message)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// LoadRulePackDir auto-discovers every *.yaml/*.yml file directly under dir
+// and loads it as a rule pack, so dropping a rules/ directory into a project
+// is enough to extend the rule set - no flag per file required. A missing
+// dir is not an error, since most projects won't have one.
+func LoadRulePackDir(dir string) ([]*PatternRule, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext == ".yaml" || ext == ".yml" {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var rules []*PatternRule
+	for _, name := range names {
+		loaded, err := LoadRulePack(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, loaded...)
+	}
+
+	return rules, nil
+}
+
+// parseRulePackYAML decodes the fixed "rules: [{id, name, ...}, ...]" shape
+// a rule pack file uses. It's a hand-rolled subset of YAML rather than a
+// vendored decoder, for the same reason CLIEvaluator shells out to the opa
+// binary instead of linking the OPA Go SDK: this tree has no go.mod to pull
+// a YAML module into. Only block-style "key: value" pairs nested under a
+// "- " list item are supported, which is all a rule pack needs.
+func parseRulePackYAML(data []byte) ([]rulePackEntry, error) {
+	var entries []rulePackEntry
+	var current *rulePackEntry
+	inRulesList := false
+
+	for lineNum, raw := range strings.Split(string(data), "\n") {
+		line := stripYAMLComment(raw)
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if !inRulesList {
+			if strings.TrimSpace(line) == "rules:" {
+				inRulesList = true
+			}
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " ")
+		switch {
+		case strings.HasPrefix(trimmed, "- "):
+			if current != nil {
+				entries = append(entries, *current)
+			}
+			current = &rulePackEntry{}
+			trimmed = strings.TrimPrefix(trimmed, "- ")
+			fallthrough
+		default:
+			if current == nil {
+				continue
+			}
+			key, value, ok := splitYAMLKeyValue(trimmed)
+			if !ok {
+				continue
+			}
+			if err := current.set(key, value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum+1, err)
+			}
+		}
+	}
+	if current != nil {
+		entries = append(entries, *current)
+	}
+
+	return entries, nil
+}
+
+func (e *rulePackEntry) set(key, value string) error {
+	switch key {
+	case "id":
+		e.id = value
+	case "name":
+		e.name = value
+	case "description":
+		e.description = value
+	case "severity":
+		e.severity = value
+	case "type":
+		e.issueType = value
+	case "pattern":
+		e.pattern = value
+	case "message":
+		e.message = value
+	default:
+		return fmt.Errorf("unknown rule pack field %q", key)
+	}
+	return nil
+}
+
+// stripYAMLComment removes a trailing "# ..." comment, ignoring '#' inside a
+// quoted string so patterns like "https://foo#frag" survive.
+func stripYAMLComment(line string) string {
+	inSingle, inDouble := false, false
+	for i, c := range line {
+		switch c {
+		case '\'':
+			if !inDouble {
+				inSingle = !inSingle
+			}
+		case '"':
+			if !inSingle {
+				inDouble = !inDouble
+			}
+		case '#':
+			if !inSingle && !inDouble && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// splitYAMLKeyValue splits a "key: value" line, unquoting value if it's
+// wrapped in single or double quotes.
+func splitYAMLKeyValue(line string) (key, value string, ok bool) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return "", "", false
+	}
+
+	key = strings.TrimSpace(line[:idx])
+	value = strings.TrimSpace(line[idx+1:])
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			value = value[1 : len(value)-1]
+		}
+	}
+	return key, value, key != ""
+}
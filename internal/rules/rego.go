@@ -0,0 +1,249 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"os/exec"
+	"reflect"
+	"strings"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// RegoEvaluator runs a compiled Rego policy against an input document and
+// returns whatever data.nada.deny produced. It's an interface, not a direct
+// call into the OPA Go SDK, because that SDK is a module this tree cannot
+// vendor without a go.mod (see AnalyzerAdapter's doc comment for the same
+// constraint) - CLIEvaluator below is the default, real implementation.
+type RegoEvaluator interface {
+	Eval(policyPath string, input map[string]interface{}) ([]RegoResult, error)
+}
+
+// RegoResult is one object returned by a policy's data.nada.deny rule.
+type RegoResult struct {
+	Msg    string `json:"msg"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Rule   string `json:"rule"`
+}
+
+// RegoRule wraps an OPA policy as a Rule, so organizations can encode
+// project-specific standards (naming, forbidden imports, architectural
+// layering) as policy-as-code loaded from config rather than compiled into
+// Nada, the same role Regal's Go AST checks play for Rego authors.
+type RegoRule struct {
+	id        string
+	name      string
+	policy    string
+	severity  string
+	issueType string
+	evaluator RegoEvaluator
+}
+
+// NewRegoRule builds a RegoRule that evaluates the policy at policyPath
+// through evaluator, tagging every issue it reports with severity and
+// issueType (the config's custom_rules "severity" and "type" fields).
+func NewRegoRule(id, policyPath, severity, issueType string, evaluator RegoEvaluator) *RegoRule {
+	return &RegoRule{
+		id:        id,
+		name:      "Custom Policy: " + id,
+		policy:    policyPath,
+		severity:  severity,
+		issueType: issueType,
+		evaluator: evaluator,
+	}
+}
+
+func (r *RegoRule) ID() string   { return r.id }
+func (r *RegoRule) Name() string { return r.name }
+func (r *RegoRule) Description() string {
+	return "Evaluates the Rego policy at " + r.policy + " against this file's AST"
+}
+
+// Check implements Rule. Like VulnDBRule's Lookup and internal/cache's
+// reads, a policy that fails to evaluate - a missing opa binary, a syntax
+// error in the .rego file - is treated as no findings rather than a fatal
+// error, so one broken custom rule doesn't take the rest of the analysis
+// down with it.
+func (r *RegoRule) Check(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue {
+	input := map[string]interface{}{
+		"path":   file,
+		"source": content,
+		"ast":    nodeToMap(node, fset),
+	}
+
+	results, err := r.evaluator.Eval(r.policy, input)
+	if err != nil {
+		return nil
+	}
+
+	issues := make([]types.Issue, 0, len(results))
+	for _, res := range results {
+		rule := res.Rule
+		if rule == "" {
+			rule = r.id
+		}
+		issues = append(issues, types.Issue{
+			Type:     r.issueType,
+			Severity: r.severity,
+			File:     file,
+			Line:     res.Line,
+			Column:   res.Column,
+			Rule:     rule,
+			Message:  res.Msg,
+		})
+	}
+
+	return issues
+}
+
+// nodeToMap serializes an ast.Node into the {kind, pos, end, children, ...}
+// shape policies see as input.ast, walking the node's exported fields via
+// reflection rather than hardcoding every *ast.XxxStmt/*ast.XxxExpr type -
+// go/ast has dozens of them and a policy author only needs the shape, not
+// bespoke Go-side support for each one.
+func nodeToMap(n ast.Node, fset *token.FileSet) map[string]interface{} {
+	v := reflect.ValueOf(n)
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	m := map[string]interface{}{
+		"kind": reflect.TypeOf(n).String(),
+		"pos":  fset.Position(n.Pos()).Line,
+		"end":  fset.Position(n.End()).Line,
+	}
+
+	var children []map[string]interface{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		fv := v.Field(i)
+
+		if child, ok := childNode(fv, fset); ok {
+			if child != nil {
+				child["field"] = field.Name
+				children = append(children, child)
+			}
+			continue
+		}
+
+		// "kind" is already used above for the node's own Go type name, so
+		// a field literally called Kind (e.g. BasicLit.Kind) is namespaced
+		// to avoid clobbering it.
+		key := strings.ToLower(field.Name)
+		if key == "kind" {
+			key = "token_kind"
+		}
+
+		switch fv.Kind() {
+		case reflect.String:
+			m[key] = fv.String()
+		case reflect.Slice:
+			for j := 0; j < fv.Len(); j++ {
+				if child, ok := childNode(fv.Index(j), fset); ok && child != nil {
+					child["field"] = field.Name
+					children = append(children, child)
+				}
+			}
+		default:
+			if tok, ok := fv.Interface().(token.Token); ok {
+				m[key] = tok.String()
+			}
+		}
+	}
+
+	if children != nil {
+		m["children"] = children
+	}
+	return m
+}
+
+// childNode reports whether fv holds an ast.Node, returning its serialized
+// form (nil for a nil node, still ok=true so callers don't fall through to
+// treating it as a scalar field).
+func childNode(fv reflect.Value, fset *token.FileSet) (map[string]interface{}, bool) {
+	if !fv.CanInterface() {
+		return nil, false
+	}
+	node, ok := fv.Interface().(ast.Node)
+	if !ok {
+		return nil, false
+	}
+	return nodeToMap(node, fset), true
+}
+
+// CLIEvaluator runs policies through the `opa` command-line binary
+// (https://www.openpolicyagent.org/), the same integration path tools that
+// don't embed the OPA Go SDK use. If opa isn't installed, Eval returns an
+// error and RegoRule.Check treats that policy as producing no issues for
+// this run rather than failing the whole analysis.
+type CLIEvaluator struct {
+	// BinPath overrides the opa binary to run; defaults to "opa" resolved
+	// via $PATH.
+	BinPath string
+}
+
+// NewCLIEvaluator builds a CLIEvaluator that shells out to opa on $PATH.
+func NewCLIEvaluator() *CLIEvaluator {
+	return &CLIEvaluator{}
+}
+
+// Eval implements RegoEvaluator.
+func (e *CLIEvaluator) Eval(policyPath string, input map[string]interface{}) ([]RegoResult, error) {
+	bin := e.BinPath
+	if bin == "" {
+		bin = "opa"
+	}
+
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(bin, "eval", "--format", "json", "--data", policyPath, "--stdin-input", "data.nada.deny")
+	cmd.Stdin = bytes.NewReader(inputJSON)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+
+	return parseOPAEvalOutput(stdout.Bytes())
+}
+
+// opaEvalOutput mirrors the subset of `opa eval --format json`'s response
+// this evaluator needs: the bound value of data.nada.deny from the first
+// result of the first expression of the first query result set.
+type opaEvalOutput struct {
+	Result []struct {
+		Expressions []struct {
+			Value []RegoResult `json:"value"`
+		} `json:"expressions"`
+	} `json:"result"`
+}
+
+func parseOPAEvalOutput(data []byte) ([]RegoResult, error) {
+	var out opaEvalOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	if len(out.Result) == 0 || len(out.Result[0].Expressions) == 0 {
+		return nil, nil
+	}
+
+	return out.Result[0].Expressions[0].Value, nil
+}
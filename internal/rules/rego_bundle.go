@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// defaultRegoSeverity and defaultRegoType are applied to a bundle- or
+// module-loaded RegoRule's issues, since (unlike config's custom_rules
+// entries) a bundle file carries no severity/type of its own - a policy
+// author wanting something else still has RegisterRule/NewRegoRule
+// directly, and can set res.Rule per finding to distinguish them.
+const (
+	defaultRegoSeverity = types.SeverityMedium
+	defaultRegoType     = types.TypeCodeSmell
+)
+
+// LoadRegoBundle auto-discovers every *.rego file directly under dir and
+// registers one RegoRule per file, ID'd after the file's base name (e.g.
+// "rules/no-panic.rego" becomes rule ID "no-panic"). This mirrors
+// LoadRulePackDir's "drop a directory in, get rules out" convention for
+// YAML pattern packs. A missing dir is not an error, since most projects
+// won't have one.
+//
+// Each file is handed to opa eval as its own `--data` policy via
+// CLIEvaluator, the same path NewRegoRule already uses - there is no
+// equivalent here to the OPA Go SDK's ast.Compiler pre-compiling every
+// module once and caching a prepared query per rule, since that SDK is a
+// module this tree cannot vendor without a go.mod (see RegoEvaluator's doc
+// comment for the same constraint); every Check call shells out to opa
+// fresh instead.
+func (e *Engine) LoadRegoBundle(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".rego" {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		id := strings.TrimSuffix(name, ".rego")
+		policyPath := filepath.Join(dir, name)
+		e.RegisterRule(NewRegoRule(id, policyPath, defaultRegoSeverity, defaultRegoType, NewCLIEvaluator()))
+	}
+
+	return nil
+}
+
+// LoadRegoModule registers a RegoRule for an in-memory policy named name,
+// writing src to a temp file first since RegoEvaluator.Eval (and the opa
+// binary it shells out to) addresses a policy by path, not by source
+// string. The temp file is left on disk for the process lifetime rather
+// than cleaned up after each Check, so repeated analysis runs (e.g.
+// --watch) don't pay a write per file per run.
+func (e *Engine) LoadRegoModule(name, src string) error {
+	dir, err := os.MkdirTemp("", "nada-rego-module-*")
+	if err != nil {
+		return fmt.Errorf("creating temp dir for rego module %q: %w", name, err)
+	}
+
+	path := filepath.Join(dir, name+".rego")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		return fmt.Errorf("writing rego module %q: %w", name, err)
+	}
+
+	e.RegisterRule(NewRegoRule(name, path, defaultRegoSeverity, defaultRegoType, NewCLIEvaluator()))
+	return nil
+}
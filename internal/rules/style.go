@@ -0,0 +1,320 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// StyleRule implements a handful of the package-level conventions
+// honnef.co/go/tools/stylecheck popularized: a single package comment, doc
+// comments that start with the name they document, lint-clean error
+// strings, and receiver-name consistency. All four need to see every file
+// of a package together - whether a package has zero or two package
+// comments, or whether a type's methods agree on a receiver name, can't be
+// answered from one file in isolation - so StyleRule is a PackageRule
+// rather than a Rule, and runs via Engine.AnalyzePackages instead of
+// AnalyzeFile.
+type StyleRule struct{}
+
+// NewStyleRule creates a new style rule.
+func NewStyleRule() *StyleRule {
+	return &StyleRule{}
+}
+
+func (r *StyleRule) ID() string   { return "style" }
+func (r *StyleRule) Name() string { return "Style Conventions" }
+func (r *StyleRule) Description() string {
+	return "Checks package comments, exported doc comments, error string format, and receiver name consistency"
+}
+
+// Check satisfies the Rule interface that PackageRule embeds, but StyleRule
+// only does anything useful with every file of a package at hand; see
+// CheckPackage.
+func (r *StyleRule) Check(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue {
+	return nil
+}
+
+// CheckPackage runs every style check against files, all of which belong
+// to the single package at pkgPath.
+func (r *StyleRule) CheckPackage(pkgPath string, files []*ast.File, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	issues = append(issues, r.checkPackageComment(pkgPath, files, fset)...)
+	issues = append(issues, r.checkExportedDocComments(files, fset)...)
+	issues = append(issues, r.checkErrorStrings(files, fset)...)
+	issues = append(issues, r.checkReceiverNames(files, fset)...)
+
+	return issues
+}
+
+// checkPackageComment requires exactly one file of a non-main package to
+// carry a package doc comment, and that comment to start with "Package
+// <name> ", reporting on the first file of the package otherwise.
+func (r *StyleRule) checkPackageComment(pkgPath string, files []*ast.File, fset *token.FileSet) []types.Issue {
+	if len(files) == 0 {
+		return nil
+	}
+
+	pkgName := files[0].Name.Name
+	if pkgName == "main" {
+		return nil
+	}
+
+	first := fset.Position(files[0].Pos())
+	var docs []*ast.File
+	for _, f := range files {
+		if f.Doc != nil {
+			docs = append(docs, f)
+		}
+	}
+
+	want := "Package " + pkgName + " "
+	switch {
+	case len(docs) == 0:
+		return []types.Issue{{
+			Type:        types.TypeCodeSmell,
+			Severity:    types.SeverityLow,
+			File:        first.Filename,
+			Line:        1,
+			Column:      1,
+			Rule:        "missing_package_comment",
+			Message:     "Missing package comment",
+			Description: "Package \"" + pkgName + "\" has no package comment; add one file with a `// " + want + "...` comment immediately above the package clause.",
+			Impact:      types.IssueImpact{EffortMinutes: 5},
+		}}
+	case len(docs) > 1:
+		return []types.Issue{{
+			Type:        types.TypeCodeSmell,
+			Severity:    types.SeverityLow,
+			File:        first.Filename,
+			Line:        1,
+			Column:      1,
+			Rule:        "multiple_package_comments",
+			Message:     "Multiple package comments",
+			Description: "Package \"" + pkgName + "\" has a package comment in more than one file; keep exactly one.",
+			Impact:      types.IssueImpact{EffortMinutes: 5},
+		}}
+	}
+
+	doc := docs[0]
+	if !strings.HasPrefix(doc.Doc.Text(), want) {
+		pos := fset.Position(doc.Doc.Pos())
+		return []types.Issue{{
+			Type:        types.TypeCodeSmell,
+			Severity:    types.SeverityLow,
+			File:        pos.Filename,
+			Line:        pos.Line,
+			Column:      1,
+			Rule:        "malformed_package_comment",
+			Message:     "Package comment doesn't start with \"Package " + pkgName + " \"",
+			Description: "stylecheck's convention is a package comment of the form \"Package " + pkgName + " ...\" so godoc renders it consistently.",
+			Impact:      types.IssueImpact{EffortMinutes: 2},
+		}}
+	}
+
+	return nil
+}
+
+// checkExportedDocComments flags every exported top-level function (not a
+// method - checkReceiverNames already covers a type's methods together with
+// its receiver-name consistency) across files whose doc comment is missing
+// or doesn't start with its own name.
+func (r *StyleRule) checkExportedDocComments(files []*ast.File, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	report := func(name string, doc *ast.CommentGroup, pos token.Pos) {
+		if !ast.IsExported(name) {
+			return
+		}
+		p := fset.Position(pos)
+		if doc == nil {
+			issues = append(issues, types.Issue{
+				Type:        types.TypeCodeSmell,
+				Severity:    types.SeverityLow,
+				File:        p.Filename,
+				Line:        p.Line,
+				Column:      1,
+				Rule:        "doc_comment_mismatch",
+				Message:     "Exported " + name + " is missing a doc comment",
+				Description: "Exported identifiers need a doc comment beginning with \"" + name + " \" so godoc can describe them.",
+				Impact:      types.IssueImpact{EffortMinutes: 3},
+			})
+			return
+		}
+		text := doc.Text()
+		if !strings.HasPrefix(text, name+" ") && strings.TrimSpace(text) != name {
+			docPos := fset.Position(doc.Pos())
+			issues = append(issues, types.Issue{
+				Type:        types.TypeCodeSmell,
+				Severity:    types.SeverityLow,
+				File:        docPos.Filename,
+				Line:        docPos.Line,
+				Column:      1,
+				Rule:        "doc_comment_mismatch",
+				Message:     "Doc comment for " + name + " doesn't start with its name",
+				Description: "stylecheck (ST1021) expects an exported identifier's doc comment to begin with \"" + name + " \".",
+				Impact:      types.IssueImpact{EffortMinutes: 2},
+			})
+		}
+	}
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			report(fn.Name.Name, fn.Doc, fn.Pos())
+		}
+	}
+
+	return issues
+}
+
+// checkErrorStrings flags string literals passed to errors.New or
+// fmt.Errorf that start with a capital letter or end with punctuation,
+// mirroring go vet's and stylecheck's error-string convention.
+func (r *StyleRule) checkErrorStrings(files []*ast.File, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if !isErrorConstructor(call) {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(lit.Value)
+			if err != nil || value == "" {
+				return true
+			}
+			if problem, ok := errorStringProblem(value); ok {
+				pos := fset.Position(call.Pos())
+				issues = append(issues, types.Issue{
+					Type:        types.TypeCodeSmell,
+					Severity:    types.SeverityLow,
+					File:        pos.Filename,
+					Line:        pos.Line,
+					Column:      pos.Column,
+					Rule:        "error_string_format",
+					Message:     "Error string " + problem,
+					Description: "Error strings should not be capitalized and should not end with punctuation, since they're often wrapped into larger sentences (golint/stylecheck ST1005).",
+					Impact:      types.IssueImpact{EffortMinutes: 2},
+				})
+			}
+			return true
+		})
+	}
+
+	return issues
+}
+
+func isErrorConstructor(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	return (pkg.Name == "errors" && sel.Sel.Name == "New") ||
+		(pkg.Name == "fmt" && sel.Sel.Name == "Errorf")
+}
+
+func errorStringProblem(value string) (string, bool) {
+	first := rune(value[0])
+	if unicode.IsUpper(first) {
+		return "starts with a capital letter", true
+	}
+	switch value[len(value)-1] {
+	case '.', '!', ':':
+		return "ends with punctuation", true
+	}
+	return "", false
+}
+
+// checkReceiverNames flags methods whose receiver name is longer than two
+// characters, is "this" or "self", or disagrees with another method of the
+// same type elsewhere in the package.
+func (r *StyleRule) checkReceiverNames(files []*ast.File, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	type method struct {
+		name string
+		pos  token.Pos
+	}
+	byType := make(map[string][]method)
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || len(fn.Recv.List[0].Names) == 0 {
+				continue
+			}
+			recv := fn.Recv.List[0]
+			typeName := receiverTypeName(recv.Type)
+			if typeName == "" {
+				continue
+			}
+			byType[typeName] = append(byType[typeName], method{name: recv.Names[0].Name, pos: recv.Names[0].Pos()})
+		}
+	}
+
+	for typeName, methods := range byType {
+		seen := make(map[string]bool)
+		for _, m := range methods {
+			seen[m.name] = true
+		}
+		inconsistent := len(seen) > 1
+
+		for _, m := range methods {
+			pos := fset.Position(m.pos)
+			switch {
+			case m.name == "this" || m.name == "self":
+				issues = append(issues, receiverIssue(pos, typeName, "receiver named \""+m.name+"\""))
+			case len(m.name) > 2:
+				issues = append(issues, receiverIssue(pos, typeName, "receiver name \""+m.name+"\" is longer than 2 characters"))
+			case inconsistent:
+				issues = append(issues, receiverIssue(pos, typeName, "receiver name \""+m.name+"\" is inconsistent across "+typeName+"'s methods"))
+			}
+		}
+	}
+
+	return issues
+}
+
+func receiverIssue(pos token.Position, typeName, problem string) types.Issue {
+	return types.Issue{
+		Type:        types.TypeCodeSmell,
+		Severity:    types.SeverityLow,
+		File:        pos.Filename,
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Rule:        "receiver_name_style",
+		Message:     "Non-idiomatic receiver name on " + typeName,
+		Description: "Receiver names should be short (1-2 characters), consistent across a type's methods, and never \"this\"/\"self\": " + problem + ".",
+		Impact:      types.IssueImpact{EffortMinutes: 2},
+	}
+}
+
+func receiverTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
@@ -0,0 +1,602 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+// This file backs SecurityRule's injection and secret-propagation checks
+// with a lightweight, intraprocedural taint analysis over each function's
+// AST. A real version of this - the kind that can follow a tainted value
+// across function and package boundaries with a precise (*ssa.Function,
+// taint-mask) summary cache - needs type-checked, whole-program SSA built
+// via golang.org/x/tools/go/packages and golang.org/x/tools/go/ssa (see the
+// AnalyzerAdapter doc comment in external.go for why those modules aren't
+// wired in yet: this tree has no go.mod to pull them into). What follows is
+// the syntax-only approximation: it tracks identifiers assigned from a
+// known source within a single function body, recurses into functions
+// declared in the same file with a memoized per-function/per-argument-mask
+// summary instead of assuming every call touching a tainted value returns
+// tainted data, stops at a recognized sanitizer, and flags the result when
+// it reaches a known sink. It already catches the common
+// `db.Query(buildQuery(userInput))` shape a raw regex over source lines
+// can't, without needing cross-file or cross-package information.
+
+// taintSourceMethods names selector methods whose result is treated as
+// user-controlled input: HTTP/gRPC request accessors, router param
+// getters, and buffered stdin reads.
+var taintSourceMethods = map[string]bool{
+	"FormValue":     true,
+	"PostFormValue": true,
+	"URLParam":      true,
+	"Param":         true,
+	"Get":           true,
+	"ReadString":    true,
+	"ReadLine":      true,
+	"ReadBytes":     true,
+	"Text":          true,
+}
+
+// sqlSinkMethods names *sql.DB/*sql.Tx methods that execute a query string.
+// Only the query/command argument itself (Args[0]) is treated as the sink
+// surface: the remaining arguments are parameterized placeholder values,
+// which is precisely what makes db.Query("... WHERE id = ?", userInput)
+// safe despite userInput being tainted.
+var sqlSinkMethods = map[string]bool{
+	"Query":           true,
+	"QueryContext":    true,
+	"QueryRow":        true,
+	"QueryRowContext": true,
+	"Exec":            true,
+	"ExecContext":     true,
+}
+
+// sanitizerCalls names functions whose result is no longer considered
+// tainted, even when built from tainted input: they either escape the
+// value for its destination context or replace it with a fixed-format
+// encoding of the original.
+var sanitizerCalls = map[string]bool{
+	"strconv.Quote":       true,
+	"html.EscapeString":   true,
+	"template.HTMLEscape": true,
+	"url.QueryEscape":     true,
+}
+
+// taintPropagatingCalls names stdlib functions whose result is tainted
+// whenever any of the arguments after the format/template string are
+// tainted - the closest syntax-only stand-in for following a Call to
+// fmt.Sprintf through an SSA Phi/BinOp chain.
+var taintPropagatingCalls = map[string]bool{
+	"fmt.Sprintf":  true,
+	"fmt.Sprint":   true,
+	"fmt.Sprintln": true,
+}
+
+// taintSource describes why an expression is considered tainted, for the
+// first step of a DataFlow path.
+func taintSource(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				if pkg.Name == "flag" {
+					return "flag." + sel.Sel.Name + "(...)", true
+				}
+				if pkg.Name == "os" && sel.Sel.Name == "Getenv" {
+					return "os.Getenv(...)", true
+				}
+			}
+			if taintSourceMethods[sel.Sel.Name] {
+				return sel.Sel.Name + "(...)", true
+			}
+		}
+	case *ast.IndexExpr:
+		if isOSArgs(e.X) {
+			return "os.Args[...]", true
+		}
+	case *ast.SelectorExpr:
+		if isOSArgs(e) {
+			return "os.Args", true
+		}
+	}
+	return "", false
+}
+
+func isOSArgs(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "os" && sel.Sel.Name == "Args"
+}
+
+// qualifiedSelectorName renders a package-level selector call's callee as
+// "pkg.Fn", or ("", false) when call isn't shaped that way.
+func qualifiedSelectorName(call *ast.CallExpr) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	return pkg.Name + "." + sel.Sel.Name, true
+}
+
+// sinkKind reports whether call is a known SQL, command-execution, or
+// template-parsing sink, returning the rule ID and a human label for the
+// message/DataFlow step, along with whether only Args[0] counts as the
+// tainted surface (true for SQL, where later args are safe placeholders).
+func sinkKind(call *ast.CallExpr) (rule, label string, firstArgOnly, ok bool) {
+	sel, isSel := call.Fun.(*ast.SelectorExpr)
+	if !isSel {
+		return "", "", false, false
+	}
+	if sqlSinkMethods[sel.Sel.Name] {
+		return "sql_injection", "database/sql." + sel.Sel.Name + "(...)", true, true
+	}
+	if pkg, isIdent := sel.X.(*ast.Ident); isIdent && pkg.Name == "exec" && sel.Sel.Name == "Command" {
+		return "command_injection", "exec.Command(...)", false, true
+	}
+	if sel.Sel.Name == "Parse" {
+		if pkg, isIdent := sel.X.(*ast.Ident); isIdent && (pkg.Name == "template") {
+			return "template_injection", "template.Parse(...)", true, true
+		}
+	}
+	return "", "", false, false
+}
+
+// checkInjectionTaint runs the intraprocedural taint tracker over every
+// function (including closures) declared in node.
+func (r *SecurityRule) checkInjectionTaint(file string, node ast.Node, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	fns := newFuncSummaries(node)
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		default:
+			return true
+		}
+		if body == nil {
+			return true
+		}
+		issues = append(issues, trackTaintInFunc(file, body, fset, fns)...)
+		return true
+	})
+
+	return issues
+}
+
+// funcSummaries memoizes, for a plain function declared in this file and a
+// set of its parameter names considered tainted on entry, whether the
+// function's return value comes out tainted. This is the AST-only stand-in
+// for the requested memoized summary keyed by (*ssa.Function, taint-mask of
+// params): it's scoped to a single file because resolving calls across
+// files needs whole-package loading, which isn't available here.
+type funcSummaries struct {
+	byName map[string]*ast.FuncDecl
+	memo   map[string]bool
+	active map[string]bool
+}
+
+func newFuncSummaries(node ast.Node) *funcSummaries {
+	fns := &funcSummaries{
+		byName: make(map[string]*ast.FuncDecl),
+		memo:   make(map[string]bool),
+		active: make(map[string]bool),
+	}
+
+	f, ok := node.(*ast.File)
+	if !ok {
+		return fns
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Body != nil {
+			fns.byName[fn.Name.Name] = fn
+		}
+	}
+
+	return fns
+}
+
+// returnsTainted reports whether calling fn with the parameters named in
+// taintedParams already tainted produces a tainted single return value. It
+// memoizes by function name plus the sorted set of tainted parameter names,
+// and treats a function it's already evaluating higher up the call stack
+// (recursion) as untainted to guarantee termination.
+func (fns *funcSummaries) returnsTainted(name string, taintedParams map[string]bool, file string, fset *token.FileSet) bool {
+	fn, ok := fns.byName[name]
+	if !ok || fn.Type.Results == nil || len(fn.Type.Results.List) != 1 {
+		return false
+	}
+
+	key := name + "|" + taintKeyOf(taintedParams)
+	if tainted, ok := fns.memo[key]; ok {
+		return tainted
+	}
+	if fns.active[key] {
+		return false
+	}
+	fns.active[key] = true
+	defer delete(fns.active, key)
+
+	seed := make(map[string][]types.Location)
+	for _, field := range fn.Type.Params.List {
+		for _, paramName := range field.Names {
+			if taintedParams[paramName.Name] {
+				pos := fset.Position(paramName.Pos())
+				seed[paramName.Name] = []types.Location{{File: file, Line: pos.Line, Message: "tainted parameter " + paramName.Name}}
+			}
+		}
+	}
+
+	tainted := false
+	finalTaint := propagateTaint(fn.Body, seed, fns, file, fset)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if tainted {
+			return false
+		}
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			return true
+		}
+		if _, ok := exprTainted(ret.Results[0], finalTaint, fns, file, fset); ok {
+			tainted = true
+		}
+		return true
+	})
+
+	fns.memo[key] = tainted
+	return tainted
+}
+
+func taintKeyOf(params map[string]bool) string {
+	names := make([]string, 0, len(params))
+	for name, on := range params {
+		if on {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// isSanitizerCall reports whether call is a recognized sanitizer, whose
+// result is treated as clean regardless of whether its own arguments were
+// tainted.
+func isSanitizerCall(call *ast.CallExpr) bool {
+	qualified, ok := qualifiedSelectorName(call)
+	return ok && sanitizerCalls[qualified]
+}
+
+// exprTainted is the shared core of flowOf: given an already-computed set
+// of tainted local names, decide whether expr is tainted and, if so, the
+// DataFlow path explaining why. It understands sanitizer calls (always
+// clean), taint-propagating format calls (tainted if any formatted
+// argument is), calls into other functions declared in this file (resolved
+// via fns' memoized summaries), and falls back to a conservative subtree
+// search for everything else (a call into a function this pass can't
+// resolve is assumed to pass tainted arguments through, matching this
+// rule's existing bias toward fewer missed injections over fewer false
+// positives).
+func exprTainted(expr ast.Expr, tainted map[string][]types.Location, fns *funcSummaries, file string, fset *token.FileSet) ([]types.Location, bool) {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if isSanitizerCall(call) {
+			return nil, false
+		}
+
+		if qualified, ok := qualifiedSelectorName(call); ok && taintPropagatingCalls[qualified] && len(call.Args) > 1 {
+			for _, arg := range call.Args[1:] {
+				if flow, ok := exprTainted(arg, tainted, fns, file, fset); ok {
+					pos := fset.Position(call.Pos())
+					step := types.Location{File: file, Line: pos.Line, Message: "via " + qualified + "(...)"}
+					return append(append([]types.Location{}, flow...), step), true
+				}
+			}
+			return nil, false
+		}
+
+		if ident, ok := call.Fun.(*ast.Ident); ok {
+			if fn, known := fns.byName[ident.Name]; known {
+				argTainted := make(map[string]bool)
+				byIndex := paramNamesByIndex(fn)
+				var flow []types.Location
+				for i, arg := range call.Args {
+					if af, ok := exprTainted(arg, tainted, fns, file, fset); ok {
+						flow = af
+						for name, paramIdx := range byIndex {
+							if paramIdx == i {
+								argTainted[name] = true
+							}
+						}
+					}
+				}
+				if fns.returnsTainted(ident.Name, argTainted, file, fset) {
+					pos := fset.Position(call.Pos())
+					step := types.Location{File: file, Line: pos.Line, Message: "via " + ident.Name + "(...)"}
+					return append(append([]types.Location{}, flow...), step), true
+				}
+				return nil, false
+			}
+		}
+	}
+
+	var flow []types.Location
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		e, ok := n.(ast.Expr)
+		if !ok {
+			return true
+		}
+		if call, ok := e.(*ast.CallExpr); ok && isSanitizerCall(call) {
+			return false
+		}
+		if desc, ok := taintSource(e); ok {
+			pos := fset.Position(e.Pos())
+			flow = []types.Location{{File: file, Line: pos.Line, Message: "source: " + desc}}
+			found = true
+			return false
+		}
+		if id, ok := e.(*ast.Ident); ok {
+			if f, ok := tainted[id.Name]; ok {
+				flow = f
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return flow, found
+}
+
+// paramNamesByIndex maps each parameter name of fn to its 0-based position
+// in the flattened parameter list.
+func paramNamesByIndex(fn *ast.FuncDecl) map[string]int {
+	out := make(map[string]int)
+	idx := 0
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			out[name.Name] = idx
+			idx++
+		}
+	}
+	return out
+}
+
+// propagateTaint walks body in source order and returns the set of local
+// variables tainted (directly or transitively) by the time it finishes,
+// starting from seed.
+func propagateTaint(body *ast.BlockStmt, seed map[string][]types.Location, fns *funcSummaries, file string, fset *token.FileSet) map[string][]types.Location {
+	tainted := make(map[string][]types.Location, len(seed))
+	for name, flow := range seed {
+		tainted[name] = flow
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for i, lhs := range assign.Lhs {
+			id, ok := lhs.(*ast.Ident)
+			if !ok || i >= len(assign.Rhs) {
+				continue
+			}
+			if flow, ok := exprTainted(assign.Rhs[i], tainted, fns, file, fset); ok {
+				step := types.Location{File: file, Line: fset.Position(assign.Pos()).Line, Message: fmt.Sprintf("assigned to %s", id.Name)}
+				tainted[id.Name] = append(append([]types.Location{}, flow...), step)
+			} else if id.Name != "_" {
+				delete(tainted, id.Name)
+			}
+		}
+		return true
+	})
+
+	return tainted
+}
+
+// trackTaintInFunc walks body in source order, remembering which local
+// variables were assigned (directly or transitively) from a taint source,
+// and reports every sink call reached by a tainted argument.
+func trackTaintInFunc(file string, body *ast.BlockStmt, fset *token.FileSet, fns *funcSummaries) []types.Issue {
+	var issues []types.Issue
+	tainted := make(map[string][]types.Location)
+
+	flowOf := func(expr ast.Expr) ([]types.Location, bool) {
+		return exprTainted(expr, tainted, fns, file, fset)
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range stmt.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if i >= len(stmt.Rhs) {
+					continue
+				}
+				if flow, ok := flowOf(stmt.Rhs[i]); ok {
+					pos := fset.Position(stmt.Pos())
+					tainted[id.Name] = append(append([]types.Location{}, flow...),
+						types.Location{File: file, Line: pos.Line, Message: fmt.Sprintf("assigned to %s", id.Name)})
+				} else if id.Name != "_" {
+					delete(tainted, id.Name)
+				}
+			}
+		case *ast.CallExpr:
+			rule, label, firstArgOnly, ok := sinkKind(stmt)
+			if !ok {
+				return true
+			}
+			args := stmt.Args
+			if firstArgOnly && len(args) > 1 {
+				args = args[:1]
+			}
+			for _, arg := range args {
+				flow, isTainted := flowOf(arg)
+				if !isTainted {
+					continue
+				}
+				pos := fset.Position(stmt.Pos())
+				path := append(append([]types.Location{}, flow...), types.Location{File: file, Line: pos.Line, Message: "sink: " + label})
+				issues = append(issues, types.Issue{
+					Type:        types.TypeVulnerability,
+					Severity:    types.SeverityHigh,
+					File:        file,
+					Line:        pos.Line,
+					Column:      fset.Position(stmt.Pos()).Column,
+					Rule:        rule,
+					Message:     "Tainted input reaches " + label,
+					Description: "A value derived from user input flows unsanitized into " + label + "; use parameterized queries or an allow-listed argument instead of building it from request data.",
+					Impact:      types.IssueImpact{EffortMinutes: 20},
+					DataFlow:    path,
+				})
+				break
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// secretEntropyThreshold and secretMinLength bound which high-entropy
+// string constants checkSecretConstantFlow reports: short or low-entropy
+// strings are too often legitimate identifiers, formats, or enum values.
+const (
+	secretEntropyThreshold = 3.5
+	secretMinLength        = 16
+)
+
+// checkSecretConstantFlow tracks string constants assigned to a name (via a
+// top-level const/var or a local `:=`/`=`) and follows simple `b := a`
+// aliasing within the same file, then flags the origin of any resulting
+// value whose Shannon entropy suggests a token or key rather than a word or
+// template string.
+func (r *SecurityRule) checkSecretConstantFlow(file string, node ast.Node, fset *token.FileSet) []types.Issue {
+	type binding struct {
+		value string
+		chain []types.Location
+	}
+	bindings := make(map[string]binding)
+
+	record := func(name string, pos token.Pos, value string, priorChain []types.Location) {
+		step := types.Location{File: file, Line: fset.Position(pos).Line, Message: fmt.Sprintf("assigned to %s", name)}
+		chain := append(append([]types.Location{}, priorChain...), step)
+		bindings[name] = binding{value: value, chain: chain}
+	}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch decl := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range decl.Names {
+				if i >= len(decl.Values) {
+					continue
+				}
+				if value, ok := stringLitValue(decl.Values[i]); ok {
+					record(name.Name, decl.Pos(), value, nil)
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range decl.Lhs {
+				if i >= len(decl.Rhs) {
+					continue
+				}
+				id, ok := lhs.(*ast.Ident)
+				if !ok || id.Name == "_" {
+					continue
+				}
+				if value, ok := stringLitValue(decl.Rhs[i]); ok {
+					record(id.Name, decl.Pos(), value, nil)
+					continue
+				}
+				if rhsID, ok := decl.Rhs[i].(*ast.Ident); ok {
+					if b, ok := bindings[rhsID.Name]; ok {
+						record(id.Name, decl.Pos(), b.value, b.chain)
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	reportedValues := make(map[string]bool)
+	var issues []types.Issue
+	for name, b := range bindings {
+		if len(b.value) < secretMinLength || reportedValues[b.value] {
+			continue
+		}
+		if shannonEntropy(b.value) < secretEntropyThreshold {
+			continue
+		}
+		reportedValues[b.value] = true
+
+		last := b.chain[len(b.chain)-1]
+		issues = append(issues, types.Issue{
+			Type:        types.TypeVulnerability,
+			Severity:    types.SeverityHigh,
+			File:        file,
+			Line:        last.Line,
+			Column:      1,
+			Rule:        "high_entropy_secret",
+			Message:     fmt.Sprintf("Possible hardcoded secret assigned to %s", name),
+			Description: "A high-entropy string constant flows into this variable without matching a known secret pattern; verify it isn't a credential and move it to config/environment if it is.",
+			Impact:      types.IssueImpact{EffortMinutes: 10},
+			DataFlow:    b.chain,
+		})
+	}
+
+	return issues
+}
+
+func stringLitValue(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// shannonEntropy returns the Shannon entropy of s, in bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	length := float64(len(s))
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
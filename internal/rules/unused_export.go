@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+
+	"github.com/chaksack/nada/internal/typecheck"
+	"github.com/chaksack/nada/internal/types"
+)
+
+// usedElsewhereFact marks a types.Object as referenced from a package
+// other than the one that defines it. It carries no data beyond its own
+// presence.
+type usedElsewhereFact struct{}
+
+func (usedElsewhereFact) AnalysisFact() {}
+
+// usageAnalyzer records, for every identifier a package references whose
+// object is defined in a different package, a usedElsewhereFact against
+// that object - the cross-package signal unusedExportAnalyzer needs and
+// the one thing a single-file, single-package rules.Rule can never see on
+// its own.
+var usageAnalyzer = &typecheck.Analyzer{
+	Name: "export_usage",
+	Run: func(pass *typecheck.Pass) (interface{}, error) {
+		for _, obj := range pass.Pkg.Info.Uses {
+			if obj == nil || obj.Pkg() == nil || obj.Pkg() == pass.Pkg.Types {
+				continue
+			}
+			pass.ExportObjectFact(obj, usedElsewhereFact{})
+		}
+		return nil, nil
+	},
+}
+
+// unusedExportAnalyzer requires usageAnalyzer to have run over every
+// package first (see typecheck.Run's ordering guarantee), so that by the
+// time it inspects a package's own exported top-level declarations, every
+// other package's references into it have already been recorded as facts.
+var unusedExportAnalyzer = &typecheck.Analyzer{
+	Name:     "unused_export",
+	Requires: []*typecheck.Analyzer{usageAnalyzer},
+	Run: func(pass *typecheck.Pass) (interface{}, error) {
+		if pass.Pkg.Types == nil || pass.Pkg.Types.Name() == "main" {
+			return nil, nil
+		}
+
+		scope := pass.Pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+			if obj == nil || !obj.Exported() {
+				continue
+			}
+			switch obj.(type) {
+			case *gotypes.Func, *gotypes.Var, *gotypes.Const, *gotypes.TypeName:
+			default:
+				continue
+			}
+
+			if _, used := pass.ImportObjectFact(obj); used {
+				continue
+			}
+			pass.Reportf(obj.Pos(), fmt.Sprintf("exported %s %q is never referenced outside package %s", kindOf(obj), name, pass.Pkg.Types.Name()))
+		}
+		return nil, nil
+	},
+}
+
+func kindOf(obj gotypes.Object) string {
+	switch obj.(type) {
+	case *gotypes.Func:
+		return "function"
+	case *gotypes.Var:
+		return "variable"
+	case *gotypes.Const:
+		return "constant"
+	case *gotypes.TypeName:
+		return "type"
+	default:
+		return "identifier"
+	}
+}
+
+// UnusedExportRule flags an exported top-level function, variable,
+// constant, or type that no other package in the module ever references -
+// a check the line-oriented rules in this package can't express, since it
+// needs type information (to resolve an identifier to the object it
+// refers to) across every package at once, not just the file or package
+// being checked.
+//
+// This is the first TypedRule built on package typecheck, the stdlib-only
+// substitute for golang.org/x/tools/go/analysis documented there; see that
+// package's doc comment for what it can't do that a real
+// packages.Load-based driver could (honor non-default build tags, follow
+// replace directives).
+type UnusedExportRule struct{}
+
+// NewUnusedExportRule builds an UnusedExportRule.
+func NewUnusedExportRule() *UnusedExportRule {
+	return &UnusedExportRule{}
+}
+
+func (r *UnusedExportRule) ID() string   { return "unused_export" }
+func (r *UnusedExportRule) Name() string { return "Unused Export" }
+func (r *UnusedExportRule) Description() string {
+	return "Flags an exported identifier that no other package in the module references"
+}
+
+// Check satisfies the Rule interface that TypedRule embeds, but
+// UnusedExportRule only does anything useful with a whole type-checked
+// *typecheck.Program at hand; see CheckProgram. Same no-op StyleRule gives
+// its own embedded Rule.Check.
+func (r *UnusedExportRule) Check(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue {
+	return nil
+}
+
+// CheckProgram runs the export-usage/unused-export analyzer pair over prog
+// and translates each resulting Diagnostic into a types.Issue.
+func (r *UnusedExportRule) CheckProgram(prog *typecheck.Program) []types.Issue {
+	diags, err := typecheck.Run(prog, []*typecheck.Analyzer{usageAnalyzer, unusedExportAnalyzer})
+	if err != nil {
+		return nil
+	}
+
+	var issues []types.Issue
+	for _, d := range diags {
+		position := prog.Fset.Position(d.Pos)
+		issues = append(issues, types.Issue{
+			Type:        types.TypeCodeSmell,
+			Severity:    types.SeverityLow,
+			File:        position.Filename,
+			Line:        position.Line,
+			Column:      position.Column,
+			Message:     d.Message,
+			Rule:        r.ID(),
+			Description: r.Description(),
+		})
+	}
+	return issues
+}
@@ -0,0 +1,237 @@
+package rules
+
+import (
+	"bufio"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/chaksack/nada/internal/types"
+	"github.com/chaksack/nada/internal/vulndb"
+)
+
+// VulnDBRule cross-references a project's go.mod dependencies against the
+// Go vulnerability database, flagging a module with a known vulnerability
+// whenever the analyzed file imports it.
+//
+// True govulncheck-style reachability - whether a *specific vulnerable
+// symbol* is actually called, not just whether its package is imported -
+// requires type-checked packages loaded via golang.org/x/tools/go/packages
+// and a call graph built from go/types, neither of which this tree can
+// pull in without a go.mod of its own (see AnalyzerAdapter's doc comment
+// for the same constraint). This rule instead uses import-level
+// reachability: an import of the affected package is enough to report the
+// vulnerability. That's conservative - it will flag modules whose
+// vulnerable symbol the file never actually calls - but needs nothing
+// beyond the standard library.
+type VulnDBRule struct {
+	source vulndb.Source
+
+	mu      sync.Mutex
+	modules map[string]map[string]string // go.mod directory -> module path -> required version
+}
+
+// NewVulnDBRule builds a VulnDBRule that looks up vulnerabilities through
+// source (an HTTP client against vuln.go.dev, a local vulndb repo clone, or
+// a cache wrapping either - see package vulndb).
+func NewVulnDBRule(source vulndb.Source) *VulnDBRule {
+	return &VulnDBRule{source: source, modules: make(map[string]map[string]string)}
+}
+
+func (r *VulnDBRule) ID() string   { return "vulndb" }
+func (r *VulnDBRule) Name() string { return "Vulnerability Database" }
+func (r *VulnDBRule) Description() string {
+	return "Flags dependencies with known vulnerabilities from the Go vulnerability database that the file actually imports"
+}
+
+// Check implements Rule.
+func (r *VulnDBRule) Check(file string, node ast.Node, content string, fset *token.FileSet) []types.Issue {
+	var issues []types.Issue
+
+	astFile, ok := node.(*ast.File)
+	if !ok {
+		return issues
+	}
+
+	requires, err := r.requiresFor(file)
+	if err != nil || len(requires) == 0 {
+		return issues
+	}
+
+	for _, imp := range astFile.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+
+		module, version := matchModule(requires, path)
+		if module == "" {
+			continue
+		}
+
+		vulns, err := r.source.Lookup(module)
+		if err != nil {
+			continue
+		}
+
+		for _, v := range vulns {
+			if !v.Affects(module, version) {
+				continue
+			}
+
+			pos := fset.Position(imp.Pos())
+			issues = append(issues, types.Issue{
+				Type:        types.TypeVulnerability,
+				Severity:    vulnSeverity(v),
+				File:        file,
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Rule:        v.ID,
+				Message:     v.ID + ": " + v.Summary,
+				Description: v.Details,
+				Impact:      types.IssueImpact{EffortMinutes: vulnEffortMinutes(v)},
+			})
+		}
+	}
+
+	return issues
+}
+
+// requiresFor returns the go.mod requirements of the module file belongs
+// to, parsing each go.mod at most once per Engine lifetime.
+func (r *VulnDBRule) requiresFor(file string) (map[string]string, error) {
+	dir, err := findGoModDir(filepath.Dir(file))
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if cached, ok := r.modules[dir]; ok {
+		r.mu.Unlock()
+		return cached, nil
+	}
+	r.mu.Unlock()
+
+	requires, err := parseGoModRequires(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.modules[dir] = requires
+	r.mu.Unlock()
+
+	return requires, nil
+}
+
+// findGoModDir walks up from dir looking for the nearest ancestor
+// containing a go.mod.
+func findGoModDir(dir string) (string, error) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", os.ErrNotExist
+		}
+		dir = parent
+	}
+}
+
+// parseGoModRequires reads the module path -> version pairs out of a
+// go.mod's require directives. It's a deliberately minimal scanner rather
+// than golang.org/x/mod/modfile - adding that dependency isn't possible in
+// this tree without a go.mod of nada's own - so replace directives and
+// build constraints on require lines aren't honored.
+func parseGoModRequires(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	requires := make(map[string]string)
+	inBlock := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		switch {
+		case line == "require (":
+			inBlock = true
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock:
+			addRequireLine(requires, line)
+		case strings.HasPrefix(line, "require "):
+			addRequireLine(requires, strings.TrimPrefix(line, "require "))
+		}
+	}
+
+	return requires, scanner.Err()
+}
+
+func addRequireLine(requires map[string]string, line string) {
+	if idx := strings.Index(line, "//"); idx >= 0 {
+		line = line[:idx]
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return
+	}
+
+	requires[fields[0]] = fields[1]
+}
+
+// matchModule returns the longest required module path that path is either
+// equal to or a subpackage of, along with its required version.
+func matchModule(requires map[string]string, path string) (module, version string) {
+	for candidate, v := range requires {
+		if candidate != path && !strings.HasPrefix(path, candidate+"/") {
+			continue
+		}
+		if len(candidate) > len(module) {
+			module, version = candidate, v
+		}
+	}
+	return module, version
+}
+
+// vulnSeverity maps a Vuln's CVSS score to this engine's severity scale,
+// defaulting to medium when the record carries no parseable numeric score.
+func vulnSeverity(v vulndb.Vuln) string {
+	score, ok := v.CVSSScore()
+	if !ok {
+		return types.SeverityMedium
+	}
+
+	switch {
+	case score >= 7:
+		return types.SeverityHigh
+	case score >= 4:
+		return types.SeverityMedium
+	default:
+		return types.SeverityLow
+	}
+}
+
+// vulnEffortMinutes estimates the time to upgrade past a vulnerability,
+// scaled by severity the same way the other rules in this package size
+// their fixes.
+func vulnEffortMinutes(v vulndb.Vuln) int {
+	switch vulnSeverity(v) {
+	case types.SeverityHigh:
+		return 60
+	case types.SeverityMedium:
+		return 30
+	default:
+		return 15
+	}
+}
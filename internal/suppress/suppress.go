@@ -0,0 +1,153 @@
+// Package suppress parses //nada:ignore-style directives out of a file's
+// comments and filters rule-reported issues against them, mirroring how
+// golangci-lint and staticcheck handle //nolint.
+//
+// Four directive forms are recognized, each taking an optional
+// comma-separated rule list (omitting the list suppresses every rule):
+//
+//	//nada:ignore rule1,rule2        - issues on the same line only
+//	//nada:ignore-next-line rule1,.. - issues on the following line only
+//	//nada:ignore-begin rule1,..     - opens a block, closed by the next
+//	//nada:ignore-end                  //nada:ignore-end in the same file
+//	//nada:ignore-file rule1,..      - every issue in the file
+package suppress
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+const (
+	lineDirective     = "nada:ignore"
+	nextLineDirective = "nada:ignore-next-line"
+	beginDirective    = "nada:ignore-begin"
+	endDirective      = "nada:ignore-end"
+	fileDirective     = "nada:ignore-file"
+)
+
+// span suppresses rules (nil meaning every rule) for every line from start
+// to end inclusive. A file's directive count is small enough that a flat
+// slice scanned per issue is effectively free, so spans don't need the
+// balanced interval tree a larger corpus would call for.
+type span struct {
+	start, end int
+	rules      map[string]bool
+	used       bool
+}
+
+func (s *span) suppresses(rule string) bool {
+	return s.rules == nil || s.rules[rule]
+}
+
+// Suppressions holds every directive found in one file, ready to filter
+// that file's issues.
+type Suppressions struct {
+	spans []*span
+}
+
+// Parse walks file's comments and builds the Suppressions that apply to it.
+func Parse(file *ast.File, fset *token.FileSet) *Suppressions {
+	s := &Suppressions{}
+
+	var blockStart int
+	var blockRules map[string]bool
+	inBlock := false
+
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			text := directiveText(c.Text)
+			line := fset.Position(c.Pos()).Line
+
+			switch {
+			case strings.HasPrefix(text, fileDirective):
+				s.spans = append(s.spans, &span{start: 1, end: 1 << 30, rules: parseRules(text, fileDirective)})
+			case strings.HasPrefix(text, nextLineDirective):
+				s.spans = append(s.spans, &span{start: line + 1, end: line + 1, rules: parseRules(text, nextLineDirective)})
+			case strings.HasPrefix(text, beginDirective):
+				blockStart, blockRules, inBlock = line, parseRules(text, beginDirective), true
+			case strings.HasPrefix(text, endDirective):
+				if inBlock {
+					s.spans = append(s.spans, &span{start: blockStart, end: line, rules: blockRules})
+					inBlock = false
+				}
+			case strings.HasPrefix(text, lineDirective):
+				s.spans = append(s.spans, &span{start: line, end: line, rules: parseRules(text, lineDirective)})
+			}
+		}
+	}
+
+	return s
+}
+
+// directiveText strips a comment's leading "//" or "/*"..."*/" marker and
+// surrounding whitespace so the directive prefixes above can be matched
+// with a plain HasPrefix.
+func directiveText(commentText string) string {
+	text := strings.TrimPrefix(commentText, "//")
+	text = strings.TrimPrefix(text, "/*")
+	text = strings.TrimSuffix(text, "*/")
+	return strings.TrimSpace(text)
+}
+
+func parseRules(text, directive string) map[string]bool {
+	rest := strings.TrimSpace(strings.TrimPrefix(text, directive))
+	if rest == "" {
+		return nil
+	}
+
+	rules := make(map[string]bool)
+	for _, rule := range strings.Split(rest, ",") {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules[rule] = true
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return rules
+}
+
+// Filter drops every issue a directive suppresses, marking each matching
+// span used so UnusedCount can report stale ones afterward.
+func (s *Suppressions) Filter(issues []types.Issue) []types.Issue {
+	if s == nil || len(s.spans) == 0 {
+		return issues
+	}
+
+	kept := make([]types.Issue, 0, len(issues))
+	for _, issue := range issues {
+		suppressed := false
+		for _, sp := range s.spans {
+			if issue.Line < sp.start || issue.Line > sp.end {
+				continue
+			}
+			if sp.suppresses(issue.Rule) {
+				sp.used = true
+				suppressed = true
+			}
+		}
+		if !suppressed {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// UnusedCount returns how many directives in this file never matched an
+// issue - a signal the ignore comment is stale and can be removed.
+func (s *Suppressions) UnusedCount() int {
+	if s == nil {
+		return 0
+	}
+
+	count := 0
+	for _, sp := range s.spans {
+		if !sp.used {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,119 @@
+package suppress
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/chaksack/nada/internal/types"
+)
+
+func parseForTest(t *testing.T, code string) (*Suppressions, *token.FileSet) {
+	t.Helper()
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse test code: %v", err)
+	}
+	return Parse(node, fset), fset
+}
+
+func TestSameLineDirectiveSuppressesOnlyListedRule(t *testing.T) {
+	code := `package main
+
+func f() {
+	password := "admin123" // nada:ignore hardcoded_secret
+}`
+
+	s, _ := parseForTest(t, code)
+	issues := []types.Issue{
+		{Rule: "hardcoded_secret", Line: 4},
+		{Rule: "short_variable_name", Line: 4},
+	}
+
+	got := s.Filter(issues)
+	if len(got) != 1 || got[0].Rule != "short_variable_name" {
+		t.Fatalf("Filter() = %+v, want only short_variable_name to survive", got)
+	}
+}
+
+func TestNextLineDirective(t *testing.T) {
+	code := `package main
+
+// nada:ignore-next-line hardcoded_secret
+var password = "admin123"`
+
+	s, _ := parseForTest(t, code)
+	got := s.Filter([]types.Issue{{Rule: "hardcoded_secret", Line: 4}})
+	if len(got) != 0 {
+		t.Fatalf("Filter() = %+v, want the next-line issue suppressed", got)
+	}
+}
+
+func TestBlockDirective(t *testing.T) {
+	code := `package main
+
+// nada:ignore-begin
+var a = "admin123"
+var b = "admin123"
+
+// nada:ignore-end
+var c = "admin123"`
+
+	s, _ := parseForTest(t, code)
+	issues := []types.Issue{
+		{Rule: "hardcoded_secret", Line: 4},
+		{Rule: "hardcoded_secret", Line: 5},
+		{Rule: "hardcoded_secret", Line: 8},
+	}
+
+	got := s.Filter(issues)
+	if len(got) != 1 || got[0].Line != 8 {
+		t.Fatalf("Filter() = %+v, want only line 8 (outside the block) to survive", got)
+	}
+}
+
+func TestFileDirectiveSuppressesEverywhere(t *testing.T) {
+	code := `// nada:ignore-file hardcoded_secret
+package main
+
+var a = "admin123"
+var b = "admin123"`
+
+	s, _ := parseForTest(t, code)
+	got := s.Filter([]types.Issue{
+		{Rule: "hardcoded_secret", Line: 4},
+		{Rule: "hardcoded_secret", Line: 5},
+	})
+	if len(got) != 0 {
+		t.Fatalf("Filter() = %+v, want every hardcoded_secret issue suppressed file-wide", got)
+	}
+}
+
+func TestDirectiveWithNoRuleListSuppressesEverything(t *testing.T) {
+	code := `package main
+
+var password = "admin123" // nada:ignore
+`
+
+	s, _ := parseForTest(t, code)
+	got := s.Filter([]types.Issue{{Rule: "hardcoded_secret", Line: 3}})
+	if len(got) != 0 {
+		t.Fatalf("Filter() = %+v, want a bare nada:ignore to suppress every rule on its line", got)
+	}
+}
+
+func TestUnusedCount(t *testing.T) {
+	code := `package main
+
+var password = "admin123" // nada:ignore hardcoded_secret
+var other = 1 // nada:ignore unused_directive
+`
+
+	s, _ := parseForTest(t, code)
+	s.Filter([]types.Issue{{Rule: "hardcoded_secret", Line: 3}})
+
+	if got := s.UnusedCount(); got != 1 {
+		t.Errorf("UnusedCount() = %d, want 1 (the unused_directive comment never matched)", got)
+	}
+}
@@ -0,0 +1,205 @@
+package typecheck
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"runtime"
+	"sync"
+)
+
+// Fact is per-object metadata one Analyzer exports so a later Analyzer -
+// possibly analyzing a different package - can read it back, standing in
+// for golang.org/x/tools/go/analysis's Fact. Real analysis facts are
+// gob-encoded so they can survive across separate `go vet` process
+// invocations; Program loads a whole module into one process, so a fact
+// here is just a value shared through memory, not serialized.
+type Fact interface {
+	AnalysisFact()
+}
+
+// Diagnostic is one finding an Analyzer reports against a source position.
+type Diagnostic struct {
+	Pos     token.Pos
+	Message string
+}
+
+// Pass is what an Analyzer's Run function receives: one package's
+// type-checked files, the results of the Analyzers it Requires for this
+// same package (ResultOf), and a fact store shared by the whole Program so
+// a fact exported while analyzing one package is visible to a later Pass
+// over a different package.
+type Pass struct {
+	Pkg      *Package
+	Fset     *token.FileSet
+	ResultOf map[*Analyzer]interface{}
+
+	facts *factStore
+	diags *[]Diagnostic
+	mu    *sync.Mutex
+}
+
+// Reportf records a diagnostic against pos.
+func (p *Pass) Reportf(pos token.Pos, message string) {
+	p.mu.Lock()
+	*p.diags = append(*p.diags, Diagnostic{Pos: pos, Message: message})
+	p.mu.Unlock()
+}
+
+// ExportObjectFact records fact against obj, visible to any later
+// ImportObjectFact call for the same object - including one made by a Pass
+// analyzing a different package - the cross-package channel a DAG of
+// Analyzers needs without a whole-program SSA build: every package in one
+// Program is checked against a shared types.Universe, so obj is the same
+// *types.Object value no matter which package's Pass exported the fact.
+func (p *Pass) ExportObjectFact(obj types.Object, fact Fact) {
+	p.facts.set(obj, fact)
+}
+
+// ImportObjectFact looks up a fact previously exported for obj.
+func (p *Pass) ImportObjectFact(obj types.Object) (Fact, bool) {
+	return p.facts.get(obj)
+}
+
+type factStore struct {
+	mu sync.Mutex
+	m  map[types.Object]Fact
+}
+
+func newFactStore() *factStore { return &factStore{m: make(map[types.Object]Fact)} }
+
+func (f *factStore) set(obj types.Object, fact Fact) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.m[obj] = fact
+}
+
+func (f *factStore) get(obj types.Object) (Fact, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.m[obj]
+	return v, ok
+}
+
+// Analyzer is one type-aware check, the scoped-down substitute for
+// analysis.Analyzer: Run is invoked once per package, in Requires order,
+// and its return value is available to any Analyzer that Requires it via
+// that package's Pass.ResultOf.
+type Analyzer struct {
+	Name     string
+	Requires []*Analyzer
+	Run      func(*Pass) (interface{}, error)
+}
+
+// Run executes analyzers against every package of prog and returns every
+// diagnostic they reported. Analyzers run in dependency order: every
+// package is analyzed by Requires-ordered analyzer A before any analyzer
+// that Requires A runs against any package, so a fact A exports while
+// analyzing one package is guaranteed visible to a dependent analyzing any
+// other package. Within one analyzer, packages are analyzed concurrently
+// by a worker pool sized to GOMAXPROCS, matching the pattern
+// rules.LinterAggregator uses for its own worker pool; the fact store and
+// diagnostics slice are guarded by their own mutexes rather than one per
+// node, since contention on either is negligible next to a package's
+// analysis time.
+func Run(prog *Program, analyzers []*Analyzer) ([]Diagnostic, error) {
+	order, err := topoSort(analyzers)
+	if err != nil {
+		return nil, err
+	}
+
+	facts := newFactStore()
+	var diags []Diagnostic
+	var diagsMu sync.Mutex
+
+	results := make(map[string]map[*Analyzer]interface{}, len(prog.Packages))
+	for importPath := range prog.Packages {
+		results[importPath] = make(map[*Analyzer]interface{})
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers < 1 {
+		workers = 1
+	}
+
+	for _, a := range order {
+		jobs := make(chan string)
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for importPath := range jobs {
+					pkg := prog.Packages[importPath]
+					if pkg.Err != nil && pkg.Types == nil {
+						continue
+					}
+					resultOf := make(map[*Analyzer]interface{}, len(a.Requires))
+					for _, req := range a.Requires {
+						resultOf[req] = results[importPath][req]
+					}
+					pass := &Pass{
+						Pkg:      pkg,
+						Fset:     prog.Fset,
+						ResultOf: resultOf,
+						facts:    facts,
+						diags:    &diags,
+						mu:       &diagsMu,
+					}
+					result, err := a.Run(pass)
+					if err != nil {
+						continue
+					}
+					diagsMu.Lock()
+					results[importPath][a] = result
+					diagsMu.Unlock()
+				}
+			}()
+		}
+		for importPath := range prog.Packages {
+			jobs <- importPath
+		}
+		close(jobs)
+		wg.Wait()
+	}
+
+	return diags, nil
+}
+
+// topoSort orders analyzers so every Analyzer appears after everything it
+// Requires, erroring on a cycle.
+func topoSort(analyzers []*Analyzer) ([]*Analyzer, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[*Analyzer]int)
+	var order []*Analyzer
+
+	var visit func(a *Analyzer) error
+	visit = func(a *Analyzer) error {
+		switch state[a] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("typecheck: cycle detected at analyzer %q", a.Name)
+		}
+		state[a] = visiting
+		for _, req := range a.Requires {
+			if err := visit(req); err != nil {
+				return err
+			}
+		}
+		state[a] = done
+		order = append(order, a)
+		return nil
+	}
+
+	for _, a := range analyzers {
+		if err := visit(a); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,246 @@
+// Package typecheck loads and type-checks every package of a Go module
+// using only the standard library's go/parser, go/types, and go/importer.
+//
+// A real migration to golang.org/x/tools/go/analysis would load the module
+// with golang.org/x/tools/go/packages, which resolves build tags, handles
+// multi-module workspaces, and reuses the go command's own export data
+// cache. That isn't possible in this tree without a go.mod of nada's own
+// (see the AnalyzerAdapter doc comment in rules/external.go for the same
+// constraint). Program is the scoped-down substitute: it discovers every
+// package under one module by walking its directory tree, type-checks each
+// with go/types, and resolves imports outside the module through
+// go/importer.Default() - the same compiler export data the go tool itself
+// produces, so stdlib and already-built dependencies resolve correctly even
+// though this loader never shells out to `go list`. What it can't do that
+// go/packages can: honor build constraints beyond the default GOOS/GOARCH,
+// or follow a replace directive to a different module's source tree.
+package typecheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Package is one type-checked package: its parsed files alongside the
+// *types.Package and *types.Info go/types produced for them - the same
+// triple an analysis.Pass carries as Pkg/Files/TypesInfo.
+type Package struct {
+	Dir        string
+	ImportPath string
+	Files      []*ast.File
+	Types      *types.Package
+	Info       *types.Info
+
+	// Err is set when this package's files failed to parse or type-check
+	// cleanly. Types and Info may still be partially populated - go/types
+	// keeps checking after an error where it can - so callers that only
+	// need best-effort type information can ignore Err rather than discard
+	// the package outright.
+	Err error
+}
+
+// Program is every package loaded from one module, keyed by import path,
+// plus the shared token.FileSet every Package's positions are relative to.
+type Program struct {
+	ModulePath string
+	ModuleDir  string
+	Fset       *token.FileSet
+	Packages   map[string]*Package
+
+	fallback types.Importer
+}
+
+// Load discovers the module containing dir (by walking up to its nearest
+// go.mod) and type-checks every package under it. A package that fails to
+// parse or type-check is still present in the returned Program, with Err
+// set, so a partial failure in one package (an unresolvable import, a
+// build-tag-gated file this loader doesn't evaluate) doesn't prevent
+// analyzing the rest of the module.
+func Load(dir string) (*Program, error) {
+	modulePath, moduleDir, err := moduleAt(dir)
+	if err != nil {
+		return nil, fmt.Errorf("typecheck: %w", err)
+	}
+
+	dirs, err := discoverPackageDirs(moduleDir, modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("typecheck: %w", err)
+	}
+
+	prog := &Program{
+		ModulePath: modulePath,
+		ModuleDir:  moduleDir,
+		Fset:       token.NewFileSet(),
+		Packages:   make(map[string]*Package, len(dirs)),
+		fallback:   importer.Default(),
+	}
+
+	for importPath, pkgDir := range dirs {
+		prog.load(importPath, pkgDir)
+	}
+
+	return prog, nil
+}
+
+// load type-checks the package at pkgDir, memoizing the result in
+// p.Packages so a package imported by several others is only parsed once.
+// It implements types.Importer so go/types can call back into it while
+// resolving an import that turns out to be another package of this same
+// module.
+func (p *Program) load(importPath, pkgDir string) (*types.Package, error) {
+	if pkg, ok := p.Packages[importPath]; ok {
+		return pkg.Types, pkg.Err
+	}
+
+	entries, err := os.ReadDir(pkgDir)
+	if err != nil {
+		pkg := &Package{Dir: pkgDir, ImportPath: importPath, Err: err}
+		p.Packages[importPath] = pkg
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		node, err := parser.ParseFile(p.Fset, filepath.Join(pkgDir, name), nil, parser.ParseComments)
+		if err != nil {
+			pkg := &Package{Dir: pkgDir, ImportPath: importPath, Err: err}
+			p.Packages[importPath] = pkg
+			return nil, err
+		}
+		files = append(files, node)
+	}
+	if len(files) == 0 {
+		err := fmt.Errorf("no buildable Go files in %s", pkgDir)
+		pkg := &Package{Dir: pkgDir, ImportPath: importPath, Err: err}
+		p.Packages[importPath] = pkg
+		return nil, err
+	}
+
+	// Registering a placeholder before Check lets Import (below) detect an
+	// import cycle instead of recursing forever; valid Go has none, but a
+	// partially-written file mid-edit might.
+	placeholder := &Package{Dir: pkgDir, ImportPath: importPath, Files: files}
+	p.Packages[importPath] = placeholder
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	var firstErr error
+	conf := types.Config{
+		Importer: p,
+		Error:    func(err error) {
+			if firstErr == nil {
+				firstErr = err
+			}
+		},
+	}
+	tpkg, checkErr := conf.Check(importPath, p.Fset, files, info)
+	if checkErr != nil && firstErr == nil {
+		firstErr = checkErr
+	}
+
+	placeholder.Types = tpkg
+	placeholder.Info = info
+	placeholder.Err = firstErr
+	return tpkg, firstErr
+}
+
+// Import resolves path for go/types: a package within this module is
+// loaded (and memoized) recursively, anything else falls back to
+// go/importer.Default()'s compiler export data.
+func (p *Program) Import(path string) (*types.Package, error) {
+	if pkg, ok := p.Packages[path]; ok {
+		if pkg.Types != nil {
+			return pkg.Types, nil
+		}
+		if pkg.Err != nil {
+			return nil, pkg.Err
+		}
+		return nil, fmt.Errorf("typecheck: import cycle resolving %s", path)
+	}
+
+	if path == p.ModulePath || strings.HasPrefix(path, p.ModulePath+"/") {
+		rel := strings.TrimPrefix(strings.TrimPrefix(path, p.ModulePath), "/")
+		return p.load(path, filepath.Join(p.ModuleDir, filepath.FromSlash(rel)))
+	}
+
+	return p.fallback.Import(path)
+}
+
+// discoverPackageDirs walks root and returns every directory containing at
+// least one non-test .go file, mapped to its module-relative import path.
+func discoverPackageDirs(root, modulePath string) (map[string]string, error) {
+	dirs := make(map[string]string)
+
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if strings.HasSuffix(path, "/vendor") || strings.HasSuffix(path, "/.git") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return nil
+		}
+		importPath := modulePath
+		if rel != "." {
+			importPath = modulePath + "/" + filepath.ToSlash(rel)
+		}
+		dirs[importPath] = dir
+		return nil
+	})
+
+	return dirs, err
+}
+
+// moduleAt finds the nearest ancestor of dir containing a go.mod and
+// returns its module directive's import path. It's a deliberately minimal
+// scanner rather than golang.org/x/mod/modfile - the same constraint
+// analyzer.moduleImportPath documents for Watch mode.
+func moduleAt(dir string) (modulePath, moduleDir string, err error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	for {
+		data, err := os.ReadFile(filepath.Join(abs, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				line = strings.TrimSpace(line)
+				if strings.HasPrefix(line, "module ") {
+					return strings.TrimSpace(strings.TrimPrefix(line, "module")), abs, nil
+				}
+			}
+			return "", "", fmt.Errorf("go.mod at %s has no module directive", abs)
+		}
+
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "", "", os.ErrNotExist
+		}
+		abs = parent
+	}
+}
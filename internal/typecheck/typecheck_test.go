@@ -0,0 +1,162 @@
+package typecheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll(%s): %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%s): %v", rel, err)
+		}
+	}
+	return dir
+}
+
+func TestLoadTypeChecksEveryPackage(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.21\n",
+		"widgets/widgets.go": `package widgets
+
+func Greet() string { return "hi" }
+`,
+		"main.go": `package main
+
+import "example.com/app/widgets"
+
+func main() {
+	_ = widgets.Greet()
+}
+`,
+	})
+
+	prog, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	for _, importPath := range []string{"example.com/app", "example.com/app/widgets"} {
+		pkg, ok := prog.Packages[importPath]
+		if !ok {
+			t.Fatalf("Packages[%q] missing", importPath)
+		}
+		if pkg.Err != nil {
+			t.Errorf("Packages[%q].Err = %v, want nil", importPath, pkg.Err)
+		}
+		if pkg.Types == nil {
+			t.Errorf("Packages[%q].Types = nil, want a checked *types.Package", importPath)
+		}
+	}
+}
+
+func TestLoadReportsPerPackageErrors(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.21\n",
+		"broken/broken.go": `package broken
+
+func Broken( {
+`,
+		"main.go": `package main
+
+func main() {}
+`,
+	})
+
+	prog, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	broken, ok := prog.Packages["example.com/app/broken"]
+	if !ok {
+		t.Fatal(`Packages["example.com/app/broken"] missing`)
+	}
+	if broken.Err == nil {
+		t.Error("broken.Err = nil, want a parse error")
+	}
+
+	main, ok := prog.Packages["example.com/app"]
+	if !ok {
+		t.Fatal(`Packages["example.com/app"] missing`)
+	}
+	if main.Err != nil {
+		t.Errorf("main.Err = %v, want nil - a broken sibling package shouldn't fail this one", main.Err)
+	}
+}
+
+// TestRunOrdersAnalyzersByRequires checks that facts exported while analyzing
+// one package under an earlier analyzer are visible to a dependent analyzer
+// analyzing a different package - the ordering guarantee the unused_export
+// rule's usageAnalyzer/unusedExportAnalyzer pair depends on.
+func TestRunOrdersAnalyzersByRequires(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.21\n",
+		"widgets/widgets.go": `package widgets
+
+func Greet() string { return "hi" }
+`,
+		"main.go": `package main
+
+import "example.com/app/widgets"
+
+func main() {
+	_ = widgets.Greet()
+}
+`,
+	})
+
+	prog, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var marker = &Analyzer{
+		Name: "marker",
+		Run: func(pass *Pass) (interface{}, error) {
+			for _, obj := range pass.Pkg.Info.Uses {
+				if obj != nil {
+					pass.ExportObjectFact(obj, testFact{})
+				}
+			}
+			return nil, nil
+		},
+	}
+	var checker = &Analyzer{
+		Name:     "checker",
+		Requires: []*Analyzer{marker},
+		Run: func(pass *Pass) (interface{}, error) {
+			if pass.Pkg.Types.Name() != "widgets" {
+				return nil, nil
+			}
+			scope := pass.Pkg.Types.Scope()
+			obj := scope.Lookup("Greet")
+			if obj == nil {
+				t.Fatal("widgets.Greet not found in package scope")
+			}
+			if _, ok := pass.ImportObjectFact(obj); !ok {
+				pass.Reportf(obj.Pos(), "Greet has no usage fact recorded")
+			}
+			return nil, nil
+		},
+	}
+
+	diags, err := Run(prog, []*Analyzer{marker, checker})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(diags) != 0 {
+		t.Errorf("Run() diags = %+v, want none - checker ran after marker saw main's usage", diags)
+	}
+}
+
+type testFact struct{}
+
+func (testFact) AnalysisFact() {}
@@ -15,6 +15,73 @@ type Issue struct {
 	Category    string      `json:"category,omitempty"`
 	Priority    string      `json:"priority,omitempty"`
 	Impact      IssueImpact `json:"impact,omitempty"`
+	Fingerprint string      `json:"fingerprint,omitempty"`
+	Owner       string      `json:"owner,omitempty"`
+	IsNew       bool        `json:"is_new,omitempty"`
+
+	// Suggestion is a proposed replacement for whatever Line/Column points
+	// at - e.g. a renamed identifier - that a future `nada fix` could apply
+	// verbatim. Empty when a rule has no mechanical fix to offer.
+	Suggestion string `json:"suggestion,omitempty"`
+
+	// Source identifies the tool that produced this issue - an external
+	// linter's name (see rules.ExternalLinter), or empty for nada's own
+	// AST-based rules. Unlike Category (a rule-ID grouping used for both
+	// native and external issues), Source exists purely to tell native
+	// findings apart from a third-party tool's.
+	Source string `json:"source,omitempty"`
+
+	// BaselineStale is set when a .nadaignore entry matched this issue's
+	// file by name but not by checksum: the file has changed since the
+	// baseline was captured, so the issue resurfaces instead of staying
+	// suppressed. See internal/ignore.File.Apply.
+	BaselineStale bool `json:"baseline_stale,omitempty"`
+
+	// DataFlow traces a tainted-value path for a source/sink-style finding
+	// (SQL injection, command injection, a secret threaded through several
+	// variables), source first and sink last. Empty for issues that aren't
+	// flow-based.
+	DataFlow []Location `json:"data_flow,omitempty"`
+
+	// Fixes is the set of mechanical edits a rule proposes to resolve this
+	// issue, the structured successor to Suggestion - see internal/fix,
+	// which applies them for `nada analyze --fix`. Empty when a rule has no
+	// fix to offer, or only the free-text Suggestion.
+	Fixes []SuggestedFix `json:"fixes,omitempty"`
+}
+
+// TextEdit replaces the half-open byte range [Start, End) of File's content
+// with NewText; Start == End is a pure insertion. Mirrors the TextEdit a
+// golang.org/x/tools/go/analysis.SuggestedFix carries, but addresses source
+// by byte offset rather than token.Pos, since a Rule only ever sees one
+// file's content and fset, not a whole-program token.FileSet it could hand
+// back to a caller.
+type TextEdit struct {
+	File    string `json:"file"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+	NewText string `json:"new_text"`
+}
+
+// SuggestedFix is a named, atomic set of edits that together resolve an
+// Issue. Edits may span several files (e.g. renaming an identifier used
+// elsewhere), and must be applied together or not at all.
+type SuggestedFix struct {
+	Message string     `json:"message"`
+	Edits   []TextEdit `json:"edits"`
+
+	// Unsafe marks a fix as semantic rather than purely mechanical - an
+	// identifier rename that could collide with an existing name, as
+	// opposed to inserting a doc comment stub. internal/fix.Fixer only
+	// applies an Unsafe fix when run with --fix=unsafe.
+	Unsafe bool `json:"unsafe,omitempty"`
+}
+
+// Location is one step in an Issue's DataFlow path.
+type Location struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
 }
 
 // IssueImpact represents the effort required to fix an issue
@@ -58,30 +125,163 @@ type Report struct {
 	IssuesSummary   map[string]int `json:"issues_summary"`
 	Trends          QualityTrends  `json:"trends,omitempty"`
 	Recommendations []string       `json:"recommendations,omitempty"`
+	Hotspots        []Hotspot      `json:"hotspots,omitempty"`
+
+	// UnusedSuppressions counts //nada:ignore-style directives that never
+	// matched an issue, across every analyzed file - a signal those
+	// comments are stale and can be removed.
+	UnusedSuppressions int `json:"unused_suppressions,omitempty"`
+}
+
+// Hotspot ranks a file by combining its recent VCS churn, cyclomatic
+// complexity, and issue count - files that change often, are complex, and
+// already carry issues are the riskiest ones to leave unrefactored.
+type Hotspot struct {
+	File        string  `json:"file"`
+	Score       float64 `json:"score"`
+	CommitCount int     `json:"commit_count"`
+	AuthorCount int     `json:"author_count"`
+	Complexity  int     `json:"complexity"`
+	IssueCount  int     `json:"issue_count"`
 }
 
 // Metrics represents code quality metrics
 type Metrics struct {
+	LinesOfCode          int               `json:"lines_of_code"`
+	CyclomaticComplexity int               `json:"cyclomatic_complexity"`
+	CognitiveComplexity  int               `json:"cognitive_complexity"`
+	CodeDuplication      float64           `json:"code_duplication"`
+	TestCoverage         float64           `json:"test_coverage"`
+	TechnicalDebt        string            `json:"technical_debt"`
+	Maintainability      string            `json:"maintainability"`
+	Reliability          string            `json:"reliability"`
+	Security             string            `json:"security"`
+	PerFunction          []FunctionMetrics `json:"per_function,omitempty"`
+}
+
+// FunctionMetrics holds per-function complexity and maintainability data, so
+// reports can flag the specific functions that are risky to change rather
+// than just a file- or project-wide average.
+type FunctionMetrics struct {
+	Name                 string  `json:"name"`
+	File                 string  `json:"file"`
+	Line                 int     `json:"line"`
 	LinesOfCode          int     `json:"lines_of_code"`
 	CyclomaticComplexity int     `json:"cyclomatic_complexity"`
-	CodeDuplication      float64 `json:"code_duplication"`
-	TestCoverage         float64 `json:"test_coverage"`
-	TechnicalDebt        string  `json:"technical_debt"`
-	Maintainability      string  `json:"maintainability"`
-	Reliability          string  `json:"reliability"`
-	Security             string  `json:"security"`
+	CognitiveComplexity  int     `json:"cognitive_complexity"`
+	HalsteadVolume       float64 `json:"halstead_volume"`
+	MaintainabilityIndex float64 `json:"maintainability_index"`
 }
 
 // AnalysisOptions represents options for code analysis
 type AnalysisOptions struct {
 	ProjectPath  string
 	OutputFile   string
+	OutputFormat string
 	CoverageFile string
 	DiffTarget   string
 	ConfigFile   string
 	ExcludeFiles []string
 	IncludeTests bool
 	Verbose      bool
+	Workers      int
+
+	// Since restricts analysis to files changed relative to a git ref (plus
+	// their same-package siblings), for fast PR-scoped runs over large
+	// repos. Empty means analyze every discovered file. See
+	// CodeAnalyzer.changedPackageFiles.
+	Since string
+
+	// NoCache disables the incremental analysis cache entirely: every file
+	// is re-analyzed regardless of content hash, and .nada-cache is left
+	// untouched. See cache.Noop.
+	NoCache bool
+
+	// CacheMaxBytes bounds the on-disk size of the incremental analysis
+	// cache; when a Save would exceed it, least-recently-used entries are
+	// evicted first. <= 0 means unbounded, the default. See cache.SetMaxBytes.
+	CacheMaxBytes int64
+
+	// DiffBase restricts analysis to files changed relative to a git ref,
+	// like Since, but goes one step further: issues are also filtered down
+	// to only those on lines the diff actually touched (see
+	// CodeAnalyzer.filterToDiffBase), so a legacy file with pre-existing
+	// issues on untouched lines doesn't fail a PR that only edited one
+	// function in it. Since and DiffBase are mutually exclusive; DiffBase
+	// takes precedence if both are set.
+	DiffBase string
+
+	// Linters names the external linters (by ExternalLinter.Name, e.g.
+	// "govet", "staticcheck", "gosec", "errcheck", "revive") to shell out
+	// to and merge into the report alongside this module's own AST-based
+	// rules. Empty means none are run - external linters are opt-in since
+	// they require binaries this module doesn't vendor or install.
+	Linters []string
+}
+
+// Output formats supported by the reporting layer
+const (
+	FormatSummary     = "summary"
+	FormatJSON        = "json"
+	FormatSARIF       = "sarif"
+	FormatJUnit       = "junit"
+	FormatCodeClimate = "codeclimate"
+	FormatSonarQube   = "sonarqube"
+	FormatMarkdown    = "markdown"
+)
+
+// ReportDiff classifies the issues of a report relative to a baseline report.
+type ReportDiff struct {
+	New       []Issue `json:"new"`
+	Fixed     []Issue `json:"fixed"`
+	Unchanged []Issue `json:"unchanged"`
+}
+
+// Diff compares r against baseline using each issue's Fingerprint, returning
+// which issues are newly introduced, fixed since the baseline, or unchanged.
+// Issues without a fingerprint are treated as always new, since they can't
+// be reliably matched against the baseline.
+func (r *Report) Diff(baseline *Report) ReportDiff {
+	var diff ReportDiff
+
+	baselineFingerprints := make(map[string]bool, len(baseline.Issues))
+	for _, issue := range baseline.Issues {
+		if issue.Fingerprint != "" {
+			baselineFingerprints[issue.Fingerprint] = true
+		}
+	}
+
+	currentFingerprints := make(map[string]bool, len(r.Issues))
+	for _, issue := range r.Issues {
+		if issue.Fingerprint != "" {
+			currentFingerprints[issue.Fingerprint] = true
+		}
+
+		if issue.Fingerprint != "" && baselineFingerprints[issue.Fingerprint] {
+			diff.Unchanged = append(diff.Unchanged, issue)
+		} else {
+			diff.New = append(diff.New, issue)
+		}
+	}
+
+	for _, issue := range baseline.Issues {
+		if issue.Fingerprint != "" && !currentFingerprints[issue.Fingerprint] {
+			diff.Fixed = append(diff.Fixed, issue)
+		}
+	}
+
+	return diff
+}
+
+// ProgressEvent reports that one file finished analysis during
+// AnalyzeProject, so a caller can render a live progress indicator (see
+// reporter.Progress) without AnalyzeProject itself depending on how that's
+// drawn.
+type ProgressEvent struct {
+	File       string
+	FilesDone  int
+	FilesTotal int
+	Issues     []Issue
 }
 
 // QualityGate represents a quality gate check
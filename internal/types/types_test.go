@@ -109,6 +109,34 @@ func TestAnalysisOptions(t *testing.T) {
 	}
 }
 
+func TestReportDiff(t *testing.T) {
+	baseline := &Report{
+		Issues: []Issue{
+			{Rule: "todo_comment", Fingerprint: "fp-fixed"},
+			{Rule: "todo_comment", Fingerprint: "fp-unchanged"},
+		},
+	}
+
+	current := &Report{
+		Issues: []Issue{
+			{Rule: "todo_comment", Fingerprint: "fp-unchanged"},
+			{Rule: "hardcoded_secret", Fingerprint: "fp-new"},
+		},
+	}
+
+	diff := current.Diff(baseline)
+
+	if len(diff.New) != 1 || diff.New[0].Fingerprint != "fp-new" {
+		t.Errorf("Diff().New = %+v, want one issue with fingerprint fp-new", diff.New)
+	}
+	if len(diff.Fixed) != 1 || diff.Fixed[0].Fingerprint != "fp-fixed" {
+		t.Errorf("Diff().Fixed = %+v, want one issue with fingerprint fp-fixed", diff.Fixed)
+	}
+	if len(diff.Unchanged) != 1 || diff.Unchanged[0].Fingerprint != "fp-unchanged" {
+		t.Errorf("Diff().Unchanged = %+v, want one issue with fingerprint fp-unchanged", diff.Unchanged)
+	}
+}
+
 func TestConstants(t *testing.T) {
 	tests := []struct {
 		name     string
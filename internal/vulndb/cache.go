@@ -0,0 +1,142 @@
+package vulndb
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheTTL is how long a cached module lookup is trusted before
+// CachingSource re-queries the underlying Source. Without an expiry, a
+// module cached as vulnerability-free would stay "clean" forever even
+// after a new vulnerability is published for it.
+const CacheTTL = 24 * time.Hour
+
+// CachingSource wraps another Source, persisting each module's lookup
+// result as a JSON file under Dir so repeat runs - and repeat files
+// importing the same module within one run - don't re-query it within
+// CacheTTL. Like internal/cache, a missing, corrupt, or expired cache
+// entry is treated as a miss rather than an error: the cache is a pure
+// optimization, and a failed write never fails the lookup it's caching.
+type CachingSource struct {
+	Source Source
+	Dir    string
+
+	// Offline, when true, makes a cache miss return no vulnerabilities
+	// instead of falling through to Source.Lookup, so --offline runs never
+	// touch the network - at the cost of silently missing modules that
+	// were never looked up before.
+	Offline bool
+
+	mu     sync.Mutex
+	loaded map[string][]Vuln
+}
+
+// cacheEntry is what gets persisted per module.
+type cacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Vulns     []Vuln    `json:"vulns"`
+}
+
+// NewCachingSource builds a CachingSource around source, caching responses
+// under dir.
+func NewCachingSource(source Source, dir string) *CachingSource {
+	return &CachingSource{Source: source, Dir: dir, loaded: make(map[string][]Vuln)}
+}
+
+// DefaultCacheDir returns the directory OSV responses are cached under by
+// default: $XDG_CACHE_HOME/nada/vulndb, or the platform cache directory
+// equivalent when XDG_CACHE_HOME isn't set (os.UserCacheDir honors it on
+// its own).
+func DefaultCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "nada", "vulndb"), nil
+}
+
+// Lookup implements Source. The underlying Source.Lookup call - a network
+// round trip for HTTPSource - deliberately runs outside c.mu, so concurrent
+// lookups for different modules (the common case when the analyzer's
+// worker pool checks many files at once) aren't serialized behind one
+// another. Two workers racing to look up the same never-before-cached
+// module will both query the Source and separately write the cache file;
+// that duplicate work is harmless and, like the rest of this cache, cheaper
+// to allow than to lock around.
+func (c *CachingSource) Lookup(module string) ([]Vuln, error) {
+	if vulns, ok := c.fromMemory(module); ok {
+		return vulns, nil
+	}
+
+	if vulns, ok := c.readCache(module); ok {
+		c.storeInMemory(module, vulns)
+		return vulns, nil
+	}
+
+	if c.Offline {
+		return nil, nil
+	}
+
+	vulns, err := c.Source.Lookup(module)
+	if err != nil {
+		return nil, err
+	}
+
+	c.storeInMemory(module, vulns)
+	c.writeCache(module, vulns)
+
+	return vulns, nil
+}
+
+func (c *CachingSource) fromMemory(module string) ([]Vuln, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	vulns, ok := c.loaded[module]
+	return vulns, ok
+}
+
+func (c *CachingSource) storeInMemory(module string, vulns []Vuln) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loaded[module] = vulns
+}
+
+func (c *CachingSource) readCache(module string) ([]Vuln, bool) {
+	data, err := os.ReadFile(c.cachePath(module))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	if time.Since(entry.FetchedAt) > CacheTTL {
+		return nil, false
+	}
+
+	return entry.Vulns, true
+}
+
+func (c *CachingSource) writeCache(module string, vulns []Vuln) {
+	data, err := json.Marshal(cacheEntry{FetchedAt: time.Now(), Vulns: vulns})
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return
+	}
+
+	_ = os.WriteFile(c.cachePath(module), data, 0644)
+}
+
+func (c *CachingSource) cachePath(module string) string {
+	return filepath.Join(c.Dir, url.QueryEscape(strings.ToLower(module))+".json")
+}
@@ -0,0 +1,65 @@
+package vulndb
+
+import (
+	"strconv"
+	"strings"
+)
+
+// compareSemver compares two Go module versions (e.g. "v1.2.3",
+// "v1.2.3-pre.1") by their major.minor.patch component, returning -1, 0, or
+// 1 the way strings.Compare does. It intentionally doesn't implement full
+// semver precedence for pre-release identifiers, since go.mod versions and
+// OSV range events are overwhelmingly plain releases or pseudo-versions,
+// both of which sort correctly by their numeric core alone.
+func compareSemver(a, b string) int {
+	ca, ra := splitSemver(a)
+	cb, rb := splitSemver(b)
+
+	for i := 0; i < 3; i++ {
+		if ca[i] != cb[i] {
+			if ca[i] < cb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// Same numeric core: a bare release outranks any pre-release/pseudo
+	// suffix of the same version (v1.2.3 > v1.2.3-pre).
+	switch {
+	case ra == rb:
+		return 0
+	case ra == "":
+		return 1
+	case rb == "":
+		return -1
+	default:
+		return strings.Compare(ra, rb)
+	}
+}
+
+// splitSemver parses a version's major/minor/patch into a 3-element array
+// and returns whatever pre-release/build suffix followed it. Unparseable
+// components default to 0 so a malformed version sorts low rather than
+// panicking.
+func splitSemver(v string) ([3]int, string) {
+	v = strings.TrimPrefix(v, "v")
+
+	var core string
+	var rest string
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		core, rest = v[:i], v[i:]
+	} else {
+		core = v
+	}
+
+	var parts [3]int
+	for i, field := range strings.SplitN(core, ".", 3) {
+		if i >= 3 {
+			break
+		}
+		parts[i], _ = strconv.Atoi(field)
+	}
+
+	return parts, rest
+}
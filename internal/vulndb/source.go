@@ -0,0 +1,154 @@
+package vulndb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultEndpoint is the public Go vulnerability database, the same one
+// govulncheck itself queries.
+const DefaultEndpoint = "https://vuln.go.dev"
+
+// Source looks up the vulnerabilities known for a module, across all
+// versions; callers narrow the result to the version under analysis via
+// Vuln.Affects.
+type Source interface {
+	Lookup(module string) ([]Vuln, error)
+}
+
+var errNotFound = errors.New("vulndb: not found")
+
+// HTTPSource queries a vulnerability database over HTTP using the same
+// two-step protocol govulncheck's client uses against vuln.go.dev: first
+// the list of vulnerability IDs known for a module, then each ID's full OSV
+// record.
+type HTTPSource struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+// NewHTTPSource builds an HTTPSource against endpoint, defaulting to
+// DefaultEndpoint when empty.
+func NewHTTPSource(endpoint string) *HTTPSource {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &HTTPSource{Endpoint: endpoint}
+}
+
+// Lookup implements Source.
+func (s *HTTPSource) Lookup(module string) ([]Vuln, error) {
+	// The module path is a URL path itself (e.g. github.com/example/mod),
+	// so only the case is normalized here - escaping the slashes with
+	// url.QueryEscape would ask the server for a literal "%2F"-encoded
+	// path segment that doesn't exist and always 404.
+	modulePath := strings.ToLower(module)
+
+	var ids []string
+	if err := s.getJSON(modulePath+".json", &ids); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	vulns := make([]Vuln, 0, len(ids))
+	for _, id := range ids {
+		var v Vuln
+		if err := s.getJSON(id+".json", &v); err != nil {
+			if errors.Is(err, errNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		vulns = append(vulns, v)
+	}
+
+	return vulns, nil
+}
+
+func (s *HTTPSource) getJSON(relPath string, out interface{}) error {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Get(strings.TrimSuffix(s.Endpoint, "/") + "/" + relPath)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vulndb: unexpected status %s fetching %s", resp.Status, relPath)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// LocalSource reads OSV records out of a local clone of the vulndb source
+// repository (https://github.com/golang/vulndb), for offline use when a
+// project has no network access - the same role govulncheck's
+// -local-cve-repo-style offline mode plays. Records are expected at
+// <RepoDir>/data/osv/<ID>.json, matching the upstream repo's layout.
+type LocalSource struct {
+	RepoDir string
+
+	once     sync.Once
+	byModule map[string][]Vuln
+	err      error
+}
+
+// NewLocalSource builds a LocalSource rooted at a local vulndb repo clone.
+func NewLocalSource(repoDir string) *LocalSource {
+	return &LocalSource{RepoDir: repoDir}
+}
+
+// Lookup implements Source.
+func (s *LocalSource) Lookup(module string) ([]Vuln, error) {
+	s.once.Do(s.load)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.byModule[module], nil
+}
+
+func (s *LocalSource) load() {
+	dir := filepath.Join(s.RepoDir, "data", "osv")
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		s.err = fmt.Errorf("vulndb: reading local repo: %w", err)
+		return
+	}
+
+	s.byModule = make(map[string][]Vuln)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		var v Vuln
+		if err := json.Unmarshal(data, &v); err != nil {
+			continue
+		}
+
+		for _, a := range v.Affected {
+			s.byModule[a.Package.Name] = append(s.byModule[a.Package.Name], v)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package vulndb models OSV-format vulnerability records for Go modules and
+// decides whether a given module version is affected, independent of where
+// the records come from (see Source in source.go for the network/offline
+// lookup implementations).
+package vulndb
+
+import "strconv"
+
+// Vuln is the subset of an OSV record this package needs: enough to decide
+// whether a module version is affected and to report the finding.
+type Vuln struct {
+	ID       string     `json:"id"`
+	Summary  string     `json:"summary"`
+	Details  string     `json:"details"`
+	Severity []Severity `json:"severity"`
+	Affected []Affected `json:"affected"`
+}
+
+// Severity is one scoring of a Vuln; OSV records may carry more than one
+// (e.g. both CVSS_V3 and CVSS_V4).
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Affected names one package and the version ranges of it that a Vuln
+// applies to.
+type Affected struct {
+	Package           Package           `json:"package"`
+	Ranges            []Range           `json:"ranges"`
+	EcosystemSpecific EcosystemSpecific `json:"ecosystem_specific"`
+}
+
+// Package identifies a module in its ecosystem ("Go" for everything this
+// package cares about).
+type Package struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+// Range is a sequence of introduced/fixed version events; a version is
+// affected if it falls after the most recent "introduced" event and before
+// the next "fixed" one.
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is a single point in a Range: exactly one of Introduced or Fixed is
+// set.
+type Event struct {
+	Introduced string `json:"introduced"`
+	Fixed      string `json:"fixed"`
+}
+
+// EcosystemSpecific carries govulncheck's own extension to OSV: the exact
+// package import paths and symbols the vulnerability lives in, used for
+// call-graph reachability analysis. This package doesn't have the
+// type-checked packages needed to consult it (see rules.VulnDBRule's doc
+// comment) but keeps the field so a future reachability pass has it ready.
+type EcosystemSpecific struct {
+	Imports []Import `json:"imports"`
+}
+
+// Import is one affected package within a module, and the symbols in it
+// that are actually vulnerable.
+type Import struct {
+	Path    string   `json:"path"`
+	Symbols []string `json:"symbols"`
+}
+
+// Affects reports whether version of module falls within any vulnerable
+// SEMVER range this Vuln records for it.
+func (v Vuln) Affects(module, version string) bool {
+	for _, a := range v.Affected {
+		if a.Package.Ecosystem != "Go" || a.Package.Name != module {
+			continue
+		}
+		for _, rng := range a.Ranges {
+			if rng.Type == "SEMVER" && rangeContains(rng, version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CVSSScore returns the first parseable numeric score among v's Severity
+// entries. OSV severities are sometimes a plain score and sometimes a CVSS
+// vector string that this package doesn't parse; ok is false in the latter
+// case and the caller should fall back to a default severity.
+func (v Vuln) CVSSScore() (score float64, ok bool) {
+	for _, s := range v.Severity {
+		if parsed, err := strconv.ParseFloat(s.Score, 64); err == nil {
+			return parsed, true
+		}
+	}
+	return 0, false
+}
+
+// rangeContains replays rng's introduced/fixed events in order and reports
+// whether version lands in a vulnerable span.
+func rangeContains(rng Range, version string) bool {
+	affected := false
+	for _, ev := range rng.Events {
+		switch {
+		case ev.Introduced != "":
+			if ev.Introduced == "0" || compareSemver(version, ev.Introduced) >= 0 {
+				affected = true
+			}
+		case ev.Fixed != "":
+			if compareSemver(version, ev.Fixed) >= 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
@@ -0,0 +1,113 @@
+package vulndb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVulnAffectsRange(t *testing.T) {
+	v := Vuln{
+		ID: "GHSA-test-1234",
+		Affected: []Affected{
+			{
+				Package: Package{Name: "github.com/example/mod", Ecosystem: "Go"},
+				Ranges: []Range{
+					{
+						Type: "SEMVER",
+						Events: []Event{
+							{Introduced: "0"},
+							{Fixed: "v1.2.4"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		version string
+		want    bool
+	}{
+		{"v1.0.0", true},
+		{"v1.2.3", true},
+		{"v1.2.4", false},
+		{"v1.3.0", false},
+	}
+
+	for _, c := range cases {
+		if got := v.Affects("github.com/example/mod", c.version); got != c.want {
+			t.Errorf("Affects(%q) = %v, want %v", c.version, got, c.want)
+		}
+	}
+
+	if v.Affects("github.com/other/mod", "v1.0.0") {
+		t.Error("Affects() matched an unrelated module")
+	}
+}
+
+func TestVulnCVSSScore(t *testing.T) {
+	v := Vuln{Severity: []Severity{{Type: "CVSS_V3", Score: "9.8"}}}
+
+	score, ok := v.CVSSScore()
+	if !ok || score != 9.8 {
+		t.Errorf("CVSSScore() = %v, %v, want 9.8, true", score, ok)
+	}
+
+	unscored := Vuln{Severity: []Severity{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L"}}}
+	if _, ok := unscored.CVSSScore(); ok {
+		t.Error("CVSSScore() parsed a non-numeric CVSS vector")
+	}
+}
+
+func TestCompareSemver(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.2.3", "v1.2.3", 0},
+		{"v1.2.3", "v1.2.4", -1},
+		{"v1.10.0", "v1.2.0", 1},
+		{"v1.2.3-pre", "v1.2.3", -1},
+	}
+
+	for _, c := range cases {
+		if got := compareSemver(c.a, c.b); got != c.want {
+			t.Errorf("compareSemver(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestLocalSourceLookup(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data", "osv")
+	writeOSVFixture(t, dir, "GHSA-aaaa-bbbb", `{
+		"id": "GHSA-aaaa-bbbb",
+		"summary": "test vuln",
+		"affected": [{"package": {"name": "github.com/example/mod", "ecosystem": "Go"}}]
+	}`)
+
+	source := NewLocalSource(filepath.Dir(filepath.Dir(dir)))
+
+	vulns, err := source.Lookup("github.com/example/mod")
+	if err != nil {
+		t.Fatalf("Lookup() error: %v", err)
+	}
+	if len(vulns) != 1 || vulns[0].ID != "GHSA-aaaa-bbbb" {
+		t.Errorf("Lookup() = %+v, want one GHSA-aaaa-bbbb record", vulns)
+	}
+
+	if vulns, _ := source.Lookup("github.com/other/mod"); len(vulns) != 0 {
+		t.Errorf("Lookup() for unrelated module = %+v, want none", vulns)
+	}
+}
+
+func writeOSVFixture(t *testing.T, dir, id, contents string) {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("creating fixture dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, id+".json"), []byte(contents), 0644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+}